@@ -287,7 +287,7 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManager
 			trustedSequencerURL = cfg.Synchronizer.TrustedSequencerURL
 		} else {
 			log.Debug("getting trusted sequencer URL from smc")
-			trustedSequencerURL, err = etherman.GetTrustedSequencerURL()
+			trustedSequencerURL, err = etherman.GetTrustedSequencerURL(context.Background())
 			if err != nil {
 				log.Fatal("error getting trusted sequencer URI. Error: %v", err)
 			}
@@ -316,7 +316,7 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if !c.IsTrustedSequencer {
 		if c.RPC.SequencerNodeURI == "" {
 			log.Debug("getting trusted sequencer URL from smc")
-			c.RPC.SequencerNodeURI, err = etherman.GetTrustedSequencerURL()
+			c.RPC.SequencerNodeURI, err = etherman.GetTrustedSequencerURL(context.Background())
 			if err != nil {
 				log.Fatal("error getting trusted sequencer URI. Error: %v", err)
 			}
@@ -104,6 +104,22 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.TimestampResolution",
 			expectedValue: types.NewDuration(10 * time.Second),
 		},
+		{
+			path:          "Sequencer.Finalizer.ProcessBatchCacheEnabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Finalizer.ProcessBatchCacheSize",
+			expectedValue: int(128),
+		},
+		{
+			path:          "Sequencer.Finalizer.MaxConcurrentProcessBatch",
+			expectedValue: int(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.ProcessBatchSlotTimeout",
+			expectedValue: types.NewDuration(0),
+		},
 		{
 			path:          "Sequencer.EffectiveGasPrice.MaxBreakEvenGasPriceDeviationPercentage",
 			expectedValue: uint64(10),
@@ -88,6 +88,8 @@ BatchRequestsLimit = 20
 SyncInterval = "1s"
 SyncChunkSize = 100
 TrustedSequencerURL = "" # If it is empty or not specified, then the value is read from the smc
+L1TraceMode = ""
+L1TraceFile = ""
 
 [Sequencer]
 WaitPeriodPoolIsEmpty = "1s"
@@ -108,6 +110,10 @@ MaxTxLifetime = "3h"
 		TimestampResolution = "10s"
 		StopSequencerOnBatchNum = 0
 		SequentialReprocessFullBatch = false
+		ProcessBatchCacheEnabled = false
+		ProcessBatchCacheSize = 128
+		MaxConcurrentProcessBatch = 0
+		ProcessBatchSlotTimeout = "0s"
 	[Sequencer.DBManager]
 		PoolRetrievalInterval = "500ms"
 		L2ReorgRetrievalInterval = "5s"
@@ -22,6 +22,12 @@ var (
 	ErrNoSigner = errors.New("no signer to authorize the transaction with")
 	// ErrMissingTrieNode means that a node is missing on the trie
 	ErrMissingTrieNode = errors.New("missing trie node")
+	// ErrForkIDNotFound means that there isn't any forkID active at the requested block
+	ErrForkIDNotFound = errors.New("forkID not found")
+	// ErrGenBlockNumberWithoutForkID means that the genesis block doesn't contain any forkID event
+	ErrGenBlockNumberWithoutForkID = errors.New("the specified genBlockNumber in config file does not contain any forkID event. Please use the proper blockNumber.")
+	// ErrGenBlockNumberNotInitialForkID means that the forkID event of the genesis block isn't the initial one
+	ErrGenBlockNumberNotInitialForkID = errors.New("the specified genBlockNumber in config file does not contain the initial forkID event")
 
 	errorsCache = map[string]error{
 		ErrGasRequiredExceedsAllowance.Error():             ErrGasRequiredExceedsAllowance,
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
@@ -28,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -83,6 +85,10 @@ func SequencedBatchesSigHash() common.Hash { return sequencedBatchesEventSignatu
 // TrustedVerifyBatchesSigHash returns the hash for the `TrustedVerifyBatches` event.
 func TrustedVerifyBatchesSigHash() common.Hash { return verifyBatchesTrustedAggregatorSignatureHash }
 
+// maxBatchRequestSize is the maximum number of calls sent in a single batched JSON-RPC request, providers
+// reject the whole request when it's bigger than their limit
+const maxBatchRequestSize = 100
+
 // EventOrder is the the type used to identify the events order
 type EventOrder string
 
@@ -144,6 +150,18 @@ type Client struct {
 	l1Cfg L1Config
 	cfg   Config
 	auth  map[common.Address]bind.TransactOpts // empty in case of read-only client
+
+	forkIDs forkIDsCache
+}
+
+// forkIDsCache keeps the forkID intervals found from the genesis block up to lastBlockNumber, so GetForkIDByBlockNumber
+// only needs to query the L1 blocks that haven't been scanned yet
+type forkIDsCache struct {
+	mutex           sync.Mutex
+	genBlockNumber  uint64
+	lastBlockNumber uint64
+	scanned         bool
+	intervals       []state.ForkIDInterval
 }
 
 // NewClient creates a new etherman.
@@ -215,7 +233,7 @@ func (etherMan *Client) VerifyGenBlockNumber(ctx context.Context, genBlockNumber
 		return false, err
 	}
 	if len(logs) == 0 {
-		return false, fmt.Errorf("the specified genBlockNumber in config file does not contain any forkID event. Please use the proper blockNumber.")
+		return false, ErrGenBlockNumberWithoutForkID
 	}
 	zkevmVersion, err := etherMan.ZkEVM.ParseUpdateZkEVMVersion(logs[0])
 	if err != nil {
@@ -223,12 +241,26 @@ func (etherMan *Client) VerifyGenBlockNumber(ctx context.Context, genBlockNumber
 		return false, err
 	}
 	if zkevmVersion.NumBatch != 0 {
-		return false, fmt.Errorf("the specified genBlockNumber in config file does not contain the initial forkID event (BatchNum: %d). Please use the proper blockNumber.", zkevmVersion.NumBatch)
+		return false, fmt.Errorf("%w (BatchNum: %d). Please use the proper blockNumber.", ErrGenBlockNumberNotInitialForkID, zkevmVersion.NumBatch)
 	}
 	metrics.VerifyGenBlockTime(time.Since(start))
 	return true, nil
 }
 
+// VerifyGenBlockNumbers verifies several candidate genesis Block Numbers and returns which of them are valid.
+// An error is only returned if a candidate can't be checked, e.g. the L1 request fails
+func (etherMan *Client) VerifyGenBlockNumbers(ctx context.Context, genBlockNumbers []uint64) (map[uint64]bool, error) {
+	valid := make(map[uint64]bool, len(genBlockNumbers))
+	for _, genBlockNumber := range genBlockNumbers {
+		ok, err := etherMan.VerifyGenBlockNumber(ctx, genBlockNumber)
+		if err != nil && !errors.Is(err, ErrGenBlockNumberWithoutForkID) && !errors.Is(err, ErrGenBlockNumberNotInitialForkID) {
+			return nil, err
+		}
+		valid[genBlockNumber] = ok
+	}
+	return valid, nil
+}
+
 // GetForks returns fork information
 func (etherMan *Client) GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error) {
 	log.Debug("Getting forkIDs from blockNumber: ", genBlockNumber)
@@ -288,6 +320,48 @@ func (etherMan *Client) GetForks(ctx context.Context, genBlockNumber uint64, las
 	return forks, nil
 }
 
+// GetForkIDByBlockNumber returns the forkID active at the given L1 block number, that is, the one set by the
+// last forkID event emitted between the genesis block and blockNumber. The genesis block number is required because
+// the forkID events are looked for on L1 starting from it. The intervals found are cached, so only the first call
+// scans L1 from genBlockNumber and the next ones just query the blocks after the last one scanned. The cached
+// intervals are dropped if genBlockNumber changes
+func (etherMan *Client) GetForkIDByBlockNumber(ctx context.Context, genBlockNumber uint64, blockNumber uint64) (uint64, error) {
+	if blockNumber < genBlockNumber {
+		return 0, fmt.Errorf("%w: block %d is before the genesis block %d", ErrForkIDNotFound, blockNumber, genBlockNumber)
+	}
+
+	cache := &etherMan.forkIDs
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if !cache.scanned || cache.genBlockNumber != genBlockNumber {
+		forks, err := etherMan.GetForks(ctx, genBlockNumber, blockNumber)
+		if err != nil {
+			return 0, err
+		}
+		cache.genBlockNumber = genBlockNumber
+		cache.lastBlockNumber = blockNumber
+		cache.scanned = true
+		cache.intervals = forks
+	} else if blockNumber > cache.lastBlockNumber {
+		forks, err := etherMan.GetForks(ctx, cache.lastBlockNumber+1, blockNumber)
+		if err != nil {
+			return 0, err
+		}
+		if len(forks) > 0 && len(cache.intervals) > 0 {
+			cache.intervals[len(cache.intervals)-1].ToBatchNumber = forks[0].FromBatchNumber - 1
+		}
+		cache.lastBlockNumber = blockNumber
+		cache.intervals = append(cache.intervals, forks...)
+	}
+
+	for i := len(cache.intervals) - 1; i >= 0; i-- {
+		if cache.intervals[i].BlockNumber <= blockNumber {
+			return cache.intervals[i].ForkId, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: no forkID event up to block %d", ErrForkIDNotFound, blockNumber)
+}
+
 // GetRollupInfoByBlockRange function retrieves the Rollup information that are included in all this ethereum blocks
 // from block x to block y.
 func (etherMan *Client) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]Block, map[common.Hash][]Order, error) {
@@ -306,6 +380,171 @@ func (etherMan *Client) GetRollupInfoByBlockRange(ctx context.Context, fromBlock
 	return blocks, blocksOrder, nil
 }
 
+// GetRollupInfoByBlockRangeWithReceipts works as GetRollupInfoByBlockRange but it also returns the receipts
+// of the L1 txs that sequenced or verified batches in the range, keyed by tx hash
+func (etherMan *Client) GetRollupInfoByBlockRangeWithReceipts(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]Block, map[common.Hash][]Order, map[common.Hash]*types.Receipt, error) {
+	blocks, blocksOrder, err := etherMan.GetRollupInfoByBlockRange(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	receipts, err := etherMan.getTxReceipts(ctx, rollupInfoTxHashes(blocks))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return blocks, blocksOrder, receipts, nil
+}
+
+// rollupInfoTxHashes returns the hashes of the L1 txs that emitted the rollup events of the blocks
+func rollupInfoTxHashes(blocks []Block) []common.Hash {
+	var txHashes []common.Hash
+	seen := make(map[common.Hash]struct{})
+	add := func(txHash common.Hash) {
+		if _, found := seen[txHash]; !found {
+			seen[txHash] = struct{}{}
+			txHashes = append(txHashes, txHash)
+		}
+	}
+	for _, block := range blocks {
+		for _, sequence := range block.SequencedBatches {
+			for _, sequencedBatch := range sequence {
+				add(sequencedBatch.TxHash)
+			}
+		}
+		for _, verifiedBatch := range block.VerifiedBatches {
+			add(verifiedBatch.TxHash)
+		}
+		for _, sequence := range block.SequencedForceBatches {
+			for _, sequencedForceBatch := range sequence {
+				add(sequencedForceBatch.TxHash)
+			}
+		}
+	}
+	return txHashes
+}
+
+// getTxReceipts gets the receipts of the txs in batched JSON-RPC requests of at most maxBatchRequestSize txs.
+// If the client doesn't support batch requests, or a batched request fails, its receipts are requested one by one
+func (etherMan *Client) getTxReceipts(ctx context.Context, txHashes []common.Hash) (map[common.Hash]*types.Receipt, error) {
+	receipts := make(map[common.Hash]*types.Receipt, len(txHashes))
+	if len(txHashes) == 0 {
+		return receipts, nil
+	}
+
+	if rpcClient, ok := etherMan.EthClient.(interface{ Client() *rpc.Client }); ok {
+		var pending []common.Hash
+		for len(txHashes) > 0 {
+			chunk := txHashes
+			if len(chunk) > maxBatchRequestSize {
+				chunk = chunk[:maxBatchRequestSize]
+			}
+			txHashes = txHashes[len(chunk):]
+			if err := getTxReceiptsBatch(ctx, rpcClient.Client(), chunk, receipts); err != nil {
+				log.Warnf("batch request of tx receipts failed, requesting them one by one. Error: %v", err)
+				pending = append(pending, chunk...)
+			}
+		}
+		txHashes = pending
+	}
+
+	for _, txHash := range txHashes {
+		receipt, err := etherMan.EthClient.TransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt of tx %s: %w", txHash.String(), err)
+		}
+		receipts[txHash] = receipt
+	}
+	return receipts, nil
+}
+
+// getTxReceiptsBatch gets the receipts of the txs in a single batched JSON-RPC request and stores them in receipts.
+// Nothing is stored if any of the receipts can't be got
+func getTxReceiptsBatch(ctx context.Context, client *rpc.Client, txHashes []common.Hash, receipts map[common.Hash]*types.Receipt) error {
+	batch := make([]rpc.BatchElem, len(txHashes))
+	batchReceipts := make([]*types.Receipt, len(txHashes))
+	for i, txHash := range txHashes {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{txHash},
+			Result: &batchReceipts[i],
+		}
+	}
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+	for i, elem := range batch {
+		if elem.Error != nil || batchReceipts[i] == nil {
+			return fmt.Errorf("error getting receipt of tx %s in batch request: %v", txHashes[i].String(), elem.Error)
+		}
+	}
+	for i, txHash := range txHashes {
+		receipts[txHash] = batchReceipts[i]
+	}
+	return nil
+}
+
+// GetRollupInfoByBlockRanges returns the rollup info of several block ranges. The logs of the ranges are
+// requested in batched JSON-RPC requests of at most maxBatchRequestSize ranges (or one by one if batching isn't
+// supported by the client). An error getting or processing a range is reported in its own result
+func (etherMan *Client) GetRollupInfoByBlockRanges(ctx context.Context, blockRanges []BlockRange) (map[BlockRange]RollupInfoByBlockRange, error) {
+	results := make(map[BlockRange]RollupInfoByBlockRange, len(blockRanges))
+	if len(blockRanges) == 0 {
+		return results, nil
+	}
+
+	rpcClient, ok := etherMan.EthClient.(interface{ Client() *rpc.Client })
+	if !ok {
+		for _, blockRange := range blockRanges {
+			toBlock := blockRange.ToBlock
+			blocks, order, err := etherMan.GetRollupInfoByBlockRange(ctx, blockRange.FromBlock, &toBlock)
+			results[blockRange] = RollupInfoByBlockRange{Blocks: blocks, Order: order, Err: err}
+		}
+		return results, nil
+	}
+
+	for len(blockRanges) > 0 {
+		chunk := blockRanges
+		if len(chunk) > maxBatchRequestSize {
+			chunk = chunk[:maxBatchRequestSize]
+		}
+		blockRanges = blockRanges[len(chunk):]
+		etherMan.getRollupInfoByBlockRangesBatch(ctx, rpcClient.Client(), chunk, results)
+	}
+	return results, nil
+}
+
+// getRollupInfoByBlockRangesBatch requests the logs of the block ranges in a single batched JSON-RPC request and
+// stores the rollup info of each range in results. If the whole request fails, its error is reported in every range
+func (etherMan *Client) getRollupInfoByBlockRangesBatch(ctx context.Context, client *rpc.Client, blockRanges []BlockRange, results map[BlockRange]RollupInfoByBlockRange) {
+	start := time.Now()
+	batch := make([]rpc.BatchElem, len(blockRanges))
+	batchLogs := make([][]types.Log, len(blockRanges))
+	for i, blockRange := range blockRanges {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getLogs",
+			Args: []interface{}{map[string]interface{}{
+				"address":   etherMan.SCAddresses,
+				"fromBlock": hexutil.EncodeUint64(blockRange.FromBlock),
+				"toBlock":   hexutil.EncodeUint64(blockRange.ToBlock),
+			}},
+			Result: &batchLogs[i],
+		}
+	}
+	err := client.BatchCallContext(ctx, batch)
+	metrics.GetEventsTime(time.Since(start))
+	for i, blockRange := range blockRanges {
+		if err != nil {
+			results[blockRange] = RollupInfoByBlockRange{Err: err}
+			continue
+		}
+		if batch[i].Error != nil {
+			results[blockRange] = RollupInfoByBlockRange{Err: batch[i].Error}
+			continue
+		}
+		blocks, order, err := etherMan.processEvents(ctx, batchLogs[i], start)
+		results[blockRange] = RollupInfoByBlockRange{Blocks: blocks, Order: order, Err: err}
+	}
+}
+
 // Order contains the event order to let the synchronizer store the information following this order.
 type Order struct {
 	Name EventOrder
@@ -319,6 +558,10 @@ func (etherMan *Client) readEvents(ctx context.Context, query ethereum.FilterQue
 	if err != nil {
 		return nil, nil, err
 	}
+	return etherMan.processEvents(ctx, logs, start)
+}
+
+func (etherMan *Client) processEvents(ctx context.Context, logs []types.Log, start time.Time) ([]Block, map[common.Hash][]Order, error) {
 	var blocks []Block
 	blocksOrder := make(map[common.Hash][]Order)
 	startProcess := time.Now()
@@ -965,14 +1208,26 @@ func (etherMan *Client) EthBlockByNumber(ctx context.Context, blockNumber uint64
 	return block, nil
 }
 
+// EthBlockByHash function retrieves the ethereum block information by ethereum block hash.
+func (etherMan *Client) EthBlockByHash(ctx context.Context, blockHash common.Hash) (*types.Block, error) {
+	block, err := etherMan.EthClient.BlockByHash(ctx, blockHash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) || err.Error() == "block does not exist in blockchain" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return block, nil
+}
+
 // GetLastBatchTimestamp function allows to retrieve the lastTimestamp value in the smc
 func (etherMan *Client) GetLastBatchTimestamp() (uint64, error) {
 	return etherMan.ZkEVM.LastTimestamp(&bind.CallOpts{Pending: false})
 }
 
 // GetLatestBatchNumber function allows to retrieve the latest proposed batch in the smc
-func (etherMan *Client) GetLatestBatchNumber() (uint64, error) {
-	return etherMan.ZkEVM.LastBatchSequenced(&bind.CallOpts{Pending: false})
+func (etherMan *Client) GetLatestBatchNumber(ctx context.Context) (uint64, error) {
+	return etherMan.ZkEVM.LastBatchSequenced(&bind.CallOpts{Pending: false, Context: ctx})
 }
 
 // GetLatestBlockNumber gets the latest block number from the ethereum
@@ -1044,8 +1299,17 @@ func (etherMan *Client) ApproveMatic(ctx context.Context, account common.Address
 }
 
 // GetTrustedSequencerURL Gets the trusted sequencer url from rollup smc
-func (etherMan *Client) GetTrustedSequencerURL() (string, error) {
-	return etherMan.ZkEVM.TrustedSequencerURL(&bind.CallOpts{Pending: false})
+func (etherMan *Client) GetTrustedSequencerURL(ctx context.Context) (string, error) {
+	return etherMan.ZkEVM.TrustedSequencerURL(&bind.CallOpts{Pending: false, Context: ctx})
+}
+
+// GetLatestGlobalExitRoot gets the last global exit root from the GlobalExitRootManager smc
+func (etherMan *Client) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error) {
+	ger, err := etherMan.GlobalExitRootManager.GetLastGlobalExitRoot(&bind.CallOpts{Pending: false, Context: ctx})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(ger), nil
 }
 
 // GetL2ChainID returns L2 Chain ID
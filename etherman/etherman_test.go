@@ -22,6 +22,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,6 +89,34 @@ func TestGEREvent(t *testing.T) {
 	assert.Equal(t, common.Hash{}, blocks[1].GlobalExitRoots[0].RollupExitRoot)
 }
 
+func TestGetLatestGlobalExitRoot(t *testing.T) {
+	// Set up testing environment
+	etherman, ethBackend, auth, _, br := newTestingEnv()
+	ctx := context.Background()
+
+	initBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	amount := big.NewInt(1000000000000000)
+	auth.Value = amount
+	_, err = br.BridgeAsset(auth, 1, auth.From, amount, common.Address{}, true, []byte{})
+	require.NoError(t, err)
+
+	// Mine the tx in a block
+	ethBackend.Commit()
+
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	finalBlockNumber := finalBlock.NumberU64()
+	blocks, _, err := etherman.GetRollupInfoByBlockRange(ctx, initBlock.NumberU64(), &finalBlockNumber)
+	require.NoError(t, err)
+
+	ger, err := etherman.GetLatestGlobalExitRoot(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, common.Hash{}, ger)
+	assert.Equal(t, blocks[1].GlobalExitRoots[0].GlobalExitRoot, ger)
+}
+
 func TestForcedBatchEvent(t *testing.T) {
 	// Set up testing environment
 	etherman, ethBackend, auth, _, _ := newTestingEnv()
@@ -197,6 +227,49 @@ func TestSequencedBatchesEvent(t *testing.T) {
 	assert.Equal(t, 0, order[blocks[3].BlockHash][0].Pos)
 }
 
+func TestGetRollupInfoByBlockRangeWithReceipts(t *testing.T) {
+	// Set up testing environment
+	etherman, ethBackend, auth, _, _ := newTestingEnv()
+
+	// Read currentBlock
+	ctx := context.Background()
+	initBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	rawTxs := "f84901843b9aca00827b0c945fbdb2315678afecb367f032d93f642f64180aa380a46057361d00000000000000000000000000000000000000000000000000000000000000048203e9808073efe1fa2d3e27f26f32208550ea9b0274d49050b816cadab05a771f4275d0242fd5d92b3fb89575c070e6c930587c520ee65a3aa8cfe382fcad20421bf51d621c"
+	tx := polygonzkevm.PolygonZkEVMBatchData{
+		GlobalExitRoot:     common.Hash{},
+		Timestamp:          initBlock.Time(),
+		MinForcedTimestamp: 0,
+		Transactions:       common.Hex2Bytes(rawTxs),
+	}
+	sequenceTx, err := etherman.ZkEVM.SequenceBatches(auth, []polygonzkevm.PolygonZkEVMBatchData{tx}, auth.From)
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	verifyTx, err := etherman.ZkEVM.VerifyBatchesTrustedAggregator(auth, uint64(0), uint64(0), uint64(1), [32]byte{}, [32]byte{}, [24][32]byte{})
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	// Now read the events with the receipts
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	finalBlockNumber := finalBlock.NumberU64()
+	blocks, order, receipts, err := etherman.GetRollupInfoByBlockRangeWithReceipts(ctx, initBlock.NumberU64(), &finalBlockNumber)
+	require.NoError(t, err)
+	expectedBlocks, expectedOrder, err := etherman.GetRollupInfoByBlockRange(ctx, initBlock.NumberU64(), &finalBlockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBlocks, blocks)
+	assert.Equal(t, expectedOrder, order)
+
+	require.Equal(t, 2, len(receipts))
+	require.NotNil(t, receipts[sequenceTx.Hash()])
+	assert.Equal(t, sequenceTx.Hash(), receipts[sequenceTx.Hash()].TxHash)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipts[sequenceTx.Hash()].Status)
+	require.NotNil(t, receipts[verifyTx.Hash()])
+	assert.Equal(t, verifyTx.Hash(), receipts[verifyTx.Hash()].TxHash)
+}
+
 func TestVerifyBatchEvent(t *testing.T) {
 	// Set up testing environment
 	etherman, ethBackend, auth, _, _ := newTestingEnv()
@@ -427,6 +500,196 @@ func TestGetForks(t *testing.T) {
 	assert.Equal(t, "v1", blocks[0].ForkIDs[0].Version)
 }
 
+func TestGetForkIDByBlockNumber(t *testing.T) {
+	// Set up testing environment
+	etherman, _, _, _, _ := newTestingEnv()
+	ctx := context.Background()
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	finalBlockNumber := finalBlock.NumberU64()
+	forks, err := etherman.GetForks(ctx, 0, finalBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(forks))
+	forkBlockNumber := forks[0].BlockNumber
+	require.Greater(t, forkBlockNumber, uint64(0))
+
+	// Block before the first fork
+	_, err = etherman.GetForkIDByBlockNumber(ctx, 0, forkBlockNumber-1)
+	require.ErrorIs(t, err, ErrForkIDNotFound)
+
+	// Block before the genesis block
+	_, err = etherman.GetForkIDByBlockNumber(ctx, forkBlockNumber, forkBlockNumber-1)
+	require.ErrorIs(t, err, ErrForkIDNotFound)
+
+	// Block of the fork
+	forkID, err := etherman.GetForkIDByBlockNumber(ctx, 0, forkBlockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), forkID)
+
+	// Block after the last fork
+	forkID, err = etherman.GetForkIDByBlockNumber(ctx, 0, finalBlockNumber+10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), forkID)
+	assert.Equal(t, finalBlockNumber+10, etherman.forkIDs.lastBlockNumber)
+	assert.Equal(t, 1, len(etherman.forkIDs.intervals))
+
+	// Block already scanned is served from the cached intervals
+	_, err = etherman.GetForkIDByBlockNumber(ctx, 0, forkBlockNumber-1)
+	require.ErrorIs(t, err, ErrForkIDNotFound)
+	assert.Equal(t, finalBlockNumber+10, etherman.forkIDs.lastBlockNumber)
+}
+
+func TestVerifyGenBlockNumbers(t *testing.T) {
+	// Set up testing environment
+	etherman, _, _, _, _ := newTestingEnv()
+	ctx := context.Background()
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	forks, err := etherman.GetForks(ctx, 0, finalBlock.NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(forks))
+	genBlockNumber := forks[0].BlockNumber
+
+	_, err = etherman.VerifyGenBlockNumber(ctx, genBlockNumber-1)
+	require.ErrorIs(t, err, ErrGenBlockNumberWithoutForkID)
+
+	valid, err := etherman.VerifyGenBlockNumbers(ctx, []uint64{genBlockNumber - 1, genBlockNumber, genBlockNumber + 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]bool{genBlockNumber - 1: false, genBlockNumber: true, genBlockNumber + 1: false}, valid)
+}
+
+func TestEthBlockByHash(t *testing.T) {
+	// Set up testing environment
+	etherman, _, _, _, _ := newTestingEnv()
+	ctx := context.Background()
+	l1Block, err := etherman.EthBlockByNumber(ctx, 1)
+	require.NoError(t, err)
+
+	block, err := etherman.EthBlockByHash(ctx, l1Block.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, l1Block.Hash(), block.Hash())
+	assert.Equal(t, l1Block.NumberU64(), block.NumberU64())
+
+	_, err = etherman.EthBlockByHash(ctx, common.HexToHash("0x1"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetRollupInfoByBlockRanges(t *testing.T) {
+	// Set up testing environment
+	etherman, _, _, _, _ := newTestingEnv()
+	ctx := context.Background()
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	finalBlockNumber := finalBlock.NumberU64()
+	forks, err := etherman.GetForks(ctx, 0, finalBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(forks))
+
+	blockRanges := []BlockRange{
+		{FromBlock: 0, ToBlock: forks[0].BlockNumber},
+		{FromBlock: forks[0].BlockNumber + 1, ToBlock: finalBlockNumber},
+	}
+	results, err := etherman.GetRollupInfoByBlockRanges(ctx, blockRanges)
+	require.NoError(t, err)
+	require.Equal(t, len(blockRanges), len(results))
+	for _, blockRange := range blockRanges {
+		toBlock := blockRange.ToBlock
+		expectedBlocks, expectedOrder, err := etherman.GetRollupInfoByBlockRange(ctx, blockRange.FromBlock, &toBlock)
+		require.NoError(t, err)
+		require.NoError(t, results[blockRange].Err)
+		assert.Equal(t, expectedBlocks, results[blockRange].Blocks)
+		assert.Equal(t, expectedOrder, results[blockRange].Order)
+	}
+	assert.Equal(t, 1, len(results[blockRanges[0]].Blocks))
+	assert.Equal(t, 0, len(results[blockRanges[1]].Blocks))
+}
+
+type getLogsService struct {
+	failingFromBlock string
+}
+
+func (s *getLogsService) GetLogs(ctx context.Context, filter map[string]interface{}) ([]types.Log, error) {
+	if filter["fromBlock"] == s.failingFromBlock {
+		return nil, fmt.Errorf("query returned more than 10000 results")
+	}
+	return []types.Log{}, nil
+}
+
+func TestGetRollupInfoByBlockRangesPartialFailure(t *testing.T) {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &getLogsService{failingFromBlock: "0x65"}))
+	defer server.Stop()
+	etherman := &Client{EthClient: ethclient.NewClient(rpc.DialInProc(server))}
+
+	okRange := BlockRange{FromBlock: 1, ToBlock: 100}
+	failingRange := BlockRange{FromBlock: 101, ToBlock: 200}
+	results, err := etherman.GetRollupInfoByBlockRanges(context.Background(), []BlockRange{okRange, failingRange})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+	assert.NoError(t, results[okRange].Err)
+	assert.Equal(t, 0, len(results[okRange].Blocks))
+	assert.ErrorContains(t, results[failingRange].Err, "query returned more than 10000 results")
+}
+
+func TestGetRollupInfoByBlockRangesBatchLimit(t *testing.T) {
+	server := rpc.NewServer()
+	server.SetBatchLimits(maxBatchRequestSize, 0)
+	require.NoError(t, server.RegisterName("eth", &getLogsService{}))
+	defer server.Stop()
+	etherman := &Client{EthClient: ethclient.NewClient(rpc.DialInProc(server))}
+
+	blockRanges := make([]BlockRange, 2*maxBatchRequestSize+1)
+	for i := range blockRanges {
+		blockRanges[i] = BlockRange{FromBlock: uint64(i) * 10, ToBlock: uint64(i)*10 + 9}
+	}
+	results, err := etherman.GetRollupInfoByBlockRanges(context.Background(), blockRanges)
+	require.NoError(t, err)
+	require.Equal(t, len(blockRanges), len(results))
+	for _, blockRange := range blockRanges {
+		assert.NoError(t, results[blockRange].Err)
+	}
+}
+
+type getReceiptService struct{}
+
+func (s *getReceiptService) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash, Logs: []*types.Log{}}, nil
+}
+
+// batchOnlyClient fails the receipt requests that are not batched
+type batchOnlyClient struct {
+	ethereumClient
+	rpcClient *rpc.Client
+}
+
+func (c *batchOnlyClient) Client() *rpc.Client {
+	return c.rpcClient
+}
+
+func (c *batchOnlyClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return nil, fmt.Errorf("receipt of tx %s requested without batching", txHash.String())
+}
+
+func TestGetTxReceiptsBatchLimit(t *testing.T) {
+	server := rpc.NewServer()
+	server.SetBatchLimits(maxBatchRequestSize, 0)
+	require.NoError(t, server.RegisterName("eth", &getReceiptService{}))
+	defer server.Stop()
+	client := rpc.DialInProc(server)
+	etherman := &Client{EthClient: &batchOnlyClient{ethereumClient: ethclient.NewClient(client), rpcClient: client}}
+
+	txHashes := make([]common.Hash, 2*maxBatchRequestSize+1)
+	for i := range txHashes {
+		txHashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	receipts, err := etherman.getTxReceipts(context.Background(), txHashes)
+	require.NoError(t, err)
+	require.Equal(t, len(txHashes), len(receipts))
+	for _, txHash := range txHashes {
+		assert.Equal(t, txHash, receipts[txHash].TxHash)
+	}
+}
+
 func TestProof(t *testing.T) {
 	proof := "0x20227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a0520227cbcef731b6cbdc0edd5850c63dc7fbc27fb58d12cd4d08298799cf66a05"
 	p, err := convertProof(proof)
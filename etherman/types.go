@@ -74,3 +74,16 @@ type ForkID struct {
 	ForkID      uint64
 	Version     string
 }
+
+// BlockRange is a range of L1 blocks, both ends included
+type BlockRange struct {
+	FromBlock uint64
+	ToBlock   uint64
+}
+
+// RollupInfoByBlockRange is the rollup info of a block range, or the error getting it
+type RollupInfoByBlockRange struct {
+	Blocks []Block
+	Order  map[common.Hash][]Order
+	Err    error
+}
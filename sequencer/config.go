@@ -70,6 +70,21 @@ type FinalizerCfg struct {
 	// SequentialReprocessFullBatch indicates if the reprocess of a closed batch (sanity check) must be done in a
 	// sequential way (instead than in parallel)
 	SequentialReprocessFullBatch bool `mapstructure:"SequentialReprocessFullBatch"`
+
+	// ProcessBatchCacheEnabled enables caching the executor responses of the processed txs, so an identical
+	// ProcessBatch request (same batch, txs, old state root and timestamp) is not sent again to the executor
+	ProcessBatchCacheEnabled bool `mapstructure:"ProcessBatchCacheEnabled"`
+
+	// ProcessBatchCacheSize is the maximum number of ProcessBatch responses kept in the cache
+	ProcessBatchCacheSize int `mapstructure:"ProcessBatchCacheSize"`
+
+	// MaxConcurrentProcessBatch is the maximum number of ProcessBatch calls sent to the executor at the same time.
+	// 0 means no limit
+	MaxConcurrentProcessBatch int `mapstructure:"MaxConcurrentProcessBatch"`
+
+	// ProcessBatchSlotTimeout is the maximum time a ProcessBatch call waits for a free slot when
+	// MaxConcurrentProcessBatch is set. 0 means it waits until the call is cancelled
+	ProcessBatchSlotTimeout types.Duration `mapstructure:"ProcessBatchSlotTimeout"`
 }
 
 // DBManagerCfg contains the DBManager's configuration properties
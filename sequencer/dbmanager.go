@@ -348,6 +348,7 @@ func (d *dbManager) GetWIPBatch(ctx context.Context) (*WipBatch, error) {
 		}
 	}
 
+	wipBatch.initialResources = getMaxRemainingResources(d.batchConstraints)
 	wipBatch.remainingResources = state.BatchResources{ZKCounters: batchZkCounters, Bytes: totalBytes}
 	return wipBatch, nil
 }
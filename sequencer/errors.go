@@ -29,4 +29,10 @@ var (
 	ErrStateRootNoMatch = errors.New("state root no match")
 	// ErrExecutorError happens when we got an executor error when processing a batch
 	ErrExecutorError = errors.New("executor error")
+	// ErrProcessBatchSlotTimeout happens when a ProcessBatch call can't get a free slot to be sent to the executor
+	// within the configured timeout
+	ErrProcessBatchSlotTimeout = errors.New("timeout waiting for a free ProcessBatch slot")
+	// ErrTxResourcesExceedMax happens when a tx uses more resources than an empty batch can hold, so it can
+	// never be added to a batch
+	ErrTxResourcesExceedMax = errors.New("transaction resources exceed the max batch resources")
 )
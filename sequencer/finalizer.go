@@ -70,6 +70,10 @@ type finalizer struct {
 	proverID                     string
 	lastPendingFlushID           uint64
 	pendingFlushIDCond           *sync.Cond
+	// ProcessBatch responses cache, nil when disabled
+	processBatchCache *processBatchCache
+	// ProcessBatch concurrency limiter wrapping the executor, nil when disabled
+	processBatchLimiter *processBatchLimiter
 }
 
 type transactionToStore struct {
@@ -93,7 +97,8 @@ type WipBatch struct {
 	stateRoot          common.Hash
 	localExitRoot      common.Hash
 	timestamp          time.Time
-	globalExitRoot     common.Hash // 0x000...0 (ZeroHash) means to not update
+	globalExitRoot     common.Hash          // 0x000...0 (ZeroHash) means to not update
+	initialResources   state.BatchResources // Resources the batch started with, to compute its utilization
 	remainingResources state.BatchResources
 	countOfTxs         int
 	closingReason      state.ClosingReason
@@ -154,6 +159,19 @@ func newFinalizer(
 
 	f.reprocessFullBatchError.Store(false)
 
+	if cfg.ProcessBatchCacheEnabled {
+		if cfg.ProcessBatchCacheSize > 0 {
+			f.processBatchCache = newProcessBatchCache(cfg.ProcessBatchCacheSize)
+		} else {
+			log.Warnf("ProcessBatchCacheEnabled is set but ProcessBatchCacheSize is %d, the ProcessBatch cache is disabled", cfg.ProcessBatchCacheSize)
+		}
+	}
+
+	if cfg.MaxConcurrentProcessBatch > 0 {
+		f.processBatchLimiter = newProcessBatchLimiter(executor, cfg.MaxConcurrentProcessBatch, cfg.ProcessBatchSlotTimeout.Duration)
+		f.executor = f.processBatchLimiter
+	}
+
 	return &f
 }
 
@@ -624,8 +642,13 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker) (errW
 	}
 
 	log.Infof("processTransaction: single tx. Batch.BatchNumber: %d, BatchNumber: %d, OldStateRoot: %s, txHash: %s, GER: %s", f.batch.batchNumber, f.processRequest.BatchNumber, f.processRequest.OldStateRoot, hashStr, f.processRequest.GlobalExitRoot.String())
-	processBatchResponse, err := f.executor.ProcessBatch(ctx, f.processRequest, true)
-	if err != nil && errors.Is(err, runtime.ErrExecutorDBError) {
+	processBatchResponse, err := f.processBatch(ctx, f.processRequest, true)
+	var slotErr *processBatchSlotError
+	if err != nil && errors.As(err, &slotErr) {
+		// The request didn't reach the executor, the tx is kept in the worker to be processed again
+		log.Warnf("failed to process transaction: %s", err)
+		return nil, err
+	} else if err != nil && (errors.Is(err, runtime.ErrExecutorDBError) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
 		log.Errorf("failed to process transaction: %s", err)
 		return nil, err
 	} else if err == nil && !processBatchResponse.IsRomLevelError && len(processBatchResponse.Responses) == 0 && tx != nil {
@@ -663,6 +686,25 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker) (errW
 	return nil, nil
 }
 
+// processBatch calls the executor ProcessBatch, serving the response from the cache when it's enabled and
+// an identical request has already been processed successfully
+func (f *finalizer) processBatch(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
+	if f.processBatchCache == nil {
+		return f.executor.ProcessBatch(ctx, request, updateMerkleTree)
+	}
+
+	if response, found := f.processBatchCache.get(request); found {
+		log.Debugf("processBatch: response found in cache for batch %d, oldStateRoot: %s", request.BatchNumber, request.OldStateRoot)
+		return response, nil
+	}
+
+	response, err := f.executor.ProcessBatch(ctx, request, updateMerkleTree)
+	if err == nil && !response.IsExecutorLevelError {
+		f.processBatchCache.put(request, response)
+	}
+	return response, err
+}
+
 // handleProcessTransactionResponse handles the response of transaction processing.
 func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *TxTracker, result *state.ProcessBatchResponse, oldStateRoot common.Hash) (errWg *sync.WaitGroup, err error) {
 	// Handle Transaction Error
@@ -675,7 +717,9 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 
 	// Check remaining resources
 	err = f.checkRemainingResources(result, tx)
-	if err != nil {
+	if errors.Is(err, ErrTxResourcesExceedMax) {
+		return f.handleTxResourcesExceedMax(ctx, tx), err
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -1065,6 +1109,7 @@ func (f *finalizer) openWIPBatch(ctx context.Context, batchNum uint64, ger, stat
 		stateRoot:          stateRoot,
 		timestamp:          openBatchResp.Timestamp,
 		globalExitRoot:     ger,
+		initialResources:   getMaxRemainingResources(f.batchConstraints),
 		remainingResources: getMaxRemainingResources(f.batchConstraints),
 		closingReason:      state.EmptyClosingReason,
 	}, err
@@ -1089,7 +1134,12 @@ func (f *finalizer) closeBatch(ctx context.Context) error {
 		BatchResources:       usedResources,
 		ClosingReason:        f.batch.closingReason,
 	}
-	return f.dbManager.CloseBatch(ctx, receipt)
+	err = f.dbManager.CloseBatch(ctx, receipt)
+	if err != nil {
+		return err
+	}
+	metrics.BatchResourcesUtilization(f.batch.remainingResources.RemainingPercent(f.batch.initialResources))
+	return nil
 }
 
 // openBatch opens a new batch in the state
@@ -1143,7 +1193,13 @@ func (f *finalizer) reprocessFullBatch(ctx context.Context, batchNum uint64, ini
 		log.Infof("reprocessFullBatch: BatchNumber: %d, Tx position %d, Tx Hash: %s", batch.BatchNumber, i, tx.Hash())
 	}
 
-	result, err := f.executor.ProcessBatch(ctx, processRequest, false)
+	var result *state.ProcessBatchResponse
+	if f.processBatchLimiter != nil {
+		// The sanity check can't be retried later, so it waits for a free slot until the context is done
+		result, err = f.processBatchLimiter.ProcessBatchWithoutTimeout(ctx, processRequest, false)
+	} else {
+		result, err = f.executor.ProcessBatch(ctx, processRequest, false)
+	}
 	if err != nil {
 		log.Errorf("reprocessFullBatch: failed to process batch %d. Error: %s", batch.BatchNumber, err)
 		f.reprocessFullBatchError.Store(true)
@@ -1249,7 +1305,17 @@ func (f *finalizer) checkRemainingResources(result *state.ProcessBatchResponse,
 
 	err := f.batch.remainingResources.Sub(usedResources)
 	if err != nil {
-		log.Infof("current transaction exceeds the batch limit, updating metadata for tx in worker and continuing")
+		if !fitsInEmptyBatch(usedResources, getMaxRemainingResources(f.batchConstraints)) {
+			log.Errorf("current transaction %s doesn't fit even in an empty batch, err: %v", tx.HashStr, err)
+			return ErrTxResourcesExceedMax
+		}
+		var underflowErr *state.ResourceUnderflowError
+		if errors.As(err, &underflowErr) {
+			log.Infof("current transaction exceeds the batch limit, resource %s exhausted (required: %d, remaining: %d), updating metadata for tx in worker and continuing",
+				underflowErr.ResourceName, underflowErr.Required, underflowErr.Remaining)
+		} else {
+			log.Infof("current transaction exceeds the batch limit, updating metadata for tx in worker and continuing")
+		}
 		start := time.Now()
 		f.worker.UpdateTxZKCounters(result.Responses[0].TxHash, tx.From, usedResources.ZKCounters)
 		metrics.WorkerProcessingTime(time.Since(start))
@@ -1259,6 +1325,32 @@ func (f *finalizer) checkRemainingResources(result *state.ProcessBatchResponse,
 	return nil
 }
 
+// handleTxResourcesExceedMax deletes from the worker a tx that can't fit in any batch and marks it as invalid in the pool
+func (f *finalizer) handleTxResourcesExceedMax(ctx context.Context, tx *TxTracker) *sync.WaitGroup {
+	start := time.Now()
+	f.worker.DeleteTx(tx.Hash, tx.From)
+	metrics.WorkerProcessingTime(time.Since(start))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		failedReason := ErrTxResourcesExceedMax.Error()
+		err := f.dbManager.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &failedReason)
+		if err != nil {
+			log.Errorf("failed to update status to invalid in the pool for tx: %s, err: %s", tx.Hash.String(), err)
+		} else {
+			metrics.TxProcessed(metrics.TxProcessedLabelInvalid, 1)
+		}
+	}()
+	return wg
+}
+
+// fitsInEmptyBatch checks if the tx resources fit in a batch that starts with the max resources
+func fitsInEmptyBatch(tx state.BatchResources, max state.BatchResources) bool {
+	return max.Sub(tx) == nil
+}
+
 // isBatchAlmostFull checks if the current batch remaining resources are under the Constraints threshold for most efficient moment to close a batch
 func (f *finalizer) isBatchAlmostFull() bool {
 	resources := f.batch.remainingResources
@@ -1293,6 +1385,7 @@ func (f *finalizer) isBatchAlmostFull() bool {
 
 	if result {
 		log.Infof("Closing batch: %d, because it reached %s threshold limit", f.batch.batchNumber, resourceDesc)
+		log.Debugf("Closing batch: %d, remaining resources percentage: %v", f.batch.batchNumber, resources.RemainingPercent(getMaxRemainingResources(f.batchConstraints)))
 		f.batch.closingReason = state.BatchAlmostFullClosingReason
 	}
 
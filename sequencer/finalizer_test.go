@@ -137,6 +137,23 @@ func TestNewFinalizer(t *testing.T) {
 	assert.Equal(t, f.batchConstraints, bc)
 }
 
+func TestNewFinalizerProcessBatchCache(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	cacheCfg := cfg
+	cacheCfg.ProcessBatchCacheEnabled = true
+	cacheCfg.ProcessBatchCacheSize = 16
+	f := newFinalizer(cacheCfg, effectiveGasPriceCfg, workerMock, dbManagerMock, executorMock, seqAddr, isSynced, closingSignalCh, bc, eventLog)
+	assert.NotNil(t, f.processBatchCache)
+
+	// A cache without room for any entry is not enabled
+	cacheCfg.ProcessBatchCacheSize = 0
+	f = newFinalizer(cacheCfg, effectiveGasPriceCfg, workerMock, dbManagerMock, executorMock, seqAddr, isSynced, closingSignalCh, bc, eventLog)
+	assert.Nil(t, f.processBatchCache)
+}
+
 func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
 	f = setupFinalizer(true)
 	ctx = context.Background()
@@ -178,6 +195,8 @@ func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
 		expectedUpdateTxCall       bool
 		expectedError              error
 		expectedUpdateTxStatus     pool.TxStatus
+		// remainingCumulativeGasUsed lowers the remaining gas of the batch when it's not zero
+		remainingCumulativeGasUsed uint64
 	}{
 		{
 			name: "Successful transaction",
@@ -213,6 +232,32 @@ func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
 		},
 		{
 			name: "Batch resources underflow err",
+			executorResponse: &state.ProcessBatchResponse{
+				UsedZkCounters: state.ZKCounters{
+					CumulativeGasUsed: 1001,
+				},
+				Responses: []*state.ProcessTransactionResponse{
+					txResponse,
+				},
+				ReadWriteAddresses: map[common.Address]*state.InfoReadWrite{
+					senderAddr: {
+						Address: senderAddr,
+						Nonce:   &nonce1,
+						Balance: big.NewInt(100),
+					},
+				},
+			},
+			remainingCumulativeGasUsed: 1000,
+			oldStateRoot:               oldHash,
+			expectedUpdateTxCall:       true,
+			expectedError: state.NewBatchRemainingResourcesUnderflowError(&state.ResourceUnderflowError{
+				ResourceName: "CumulativeGasUsed",
+				Remaining:    1000,
+				Required:     1001,
+			}, cumulativeGasErr.Error()),
+		},
+		{
+			name: "Tx resources exceed max batch resources err",
 			executorResponse: &state.ProcessBatchResponse{
 				UsedZkCounters: state.ZKCounters{
 					CumulativeGasUsed: f.batch.remainingResources.ZKCounters.CumulativeGasUsed + 1,
@@ -228,9 +273,10 @@ func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
 					},
 				},
 			},
-			oldStateRoot:         oldHash,
-			expectedUpdateTxCall: true,
-			expectedError:        state.NewBatchRemainingResourcesUnderflowError(cumulativeGasErr, cumulativeGasErr.Error()),
+			oldStateRoot:           oldHash,
+			expectedDeleteTxCall:   true,
+			expectedUpdateTxStatus: pool.TxStatusInvalid,
+			expectedError:          ErrTxResourcesExceedMax,
 		},
 		{
 			name: "Intrinsic err",
@@ -290,6 +336,10 @@ func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
 			if tc.expectedDeleteTxCall {
 				workerMock.On("DeleteTx", txTracker.Hash, txTracker.From).Return().Once()
 			}
+			f.batch.remainingResources = getMaxRemainingResources(bc)
+			if tc.remainingCumulativeGasUsed != 0 {
+				f.batch.remainingResources.ZKCounters.CumulativeGasUsed = tc.remainingCumulativeGasUsed
+			}
 			if tc.expectedMoveToNotReadyCall {
 				addressInfo := tc.executorResponse.ReadWriteAddresses[senderAddr]
 				workerMock.On("MoveTxToNotReady", txHash, senderAddr, addressInfo.Nonce, addressInfo.Balance).Return([]*TxTracker{}).Once()
@@ -369,6 +419,7 @@ func TestFinalizer_newWIPBatch(t *testing.T) {
 		initialStateRoot:   newHash,
 		stateRoot:          newHash,
 		timestamp:          now(),
+		initialResources:   getMaxRemainingResources(f.batchConstraints),
 		remainingResources: getMaxRemainingResources(f.batchConstraints),
 	}
 	closeBatchParams := ClosingBatchParameters{
@@ -615,6 +666,7 @@ func TestFinalizer_syncWithState(t *testing.T) {
 				stateRoot:          oldHash,
 				timestamp:          testNow(),
 				globalExitRoot:     oldHash,
+				initialResources:   getMaxRemainingResources(f.batchConstraints),
 				remainingResources: getMaxRemainingResources(f.batchConstraints),
 			},
 			expectedProcessingCtx: state.ProcessingContext{
@@ -638,6 +690,7 @@ func TestFinalizer_syncWithState(t *testing.T) {
 				stateRoot:          oldHash,
 				timestamp:          testNow(),
 				globalExitRoot:     oldHash,
+				initialResources:   getMaxRemainingResources(f.batchConstraints),
 				remainingResources: getMaxRemainingResources(f.batchConstraints),
 			},
 			expectedProcessingCtx: state.ProcessingContext{
@@ -1025,6 +1078,7 @@ func TestFinalizer_openWIPBatch(t *testing.T) {
 		stateRoot:          oldHash,
 		timestamp:          now(),
 		globalExitRoot:     oldHash,
+		initialResources:   getMaxRemainingResources(f.batchConstraints),
 		remainingResources: getMaxRemainingResources(f.batchConstraints),
 	}
 	testCases := []struct {
@@ -1327,11 +1381,21 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 			expectedWorkerUpdate: true,
 			expectedTxTracker:    &TxTracker{RawTx: make([]byte, 0)},
 		},
+		{
+			name: "Tx Doesn't Fit In Empty Batch",
+			remaining: state.BatchResources{
+				Bytes: 0,
+			},
+			expectedErr:          ErrTxResourcesExceedMax,
+			expectedWorkerUpdate: false,
+			expectedTxTracker:    &TxTracker{RawTx: make([]byte, bc.MaxBatchBytesSize+1)},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// arrange
+			workerMock.Calls = nil
 			f.batch.remainingResources = tc.remaining
 			dbManagerMock.On("AddEvent", ctx, mock.Anything, nil).Return(nil)
 			if tc.expectedWorkerUpdate {
@@ -1357,6 +1421,16 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 	}
 }
 
+func TestFinalizer_fitsInEmptyBatch(t *testing.T) {
+	max := getMaxRemainingResources(bc)
+
+	assert.True(t, fitsInEmptyBatch(state.BatchResources{Bytes: bc.MaxBatchBytesSize, ZKCounters: state.ZKCounters{UsedSteps: bc.MaxSteps}}, max))
+	assert.False(t, fitsInEmptyBatch(state.BatchResources{Bytes: bc.MaxBatchBytesSize + 1}, max))
+	assert.False(t, fitsInEmptyBatch(state.BatchResources{ZKCounters: state.ZKCounters{UsedKeccakHashes: bc.MaxKeccakHashes + 1}}, max))
+	// max is not modified
+	assert.Equal(t, getMaxRemainingResources(bc), max)
+}
+
 func TestFinalizer_handleTransactionError(t *testing.T) {
 	// arrange
 	f = setupFinalizer(true)
@@ -1585,6 +1659,155 @@ func Test_processTransaction(t *testing.T) {
 	}
 }
 
+func TestFinalizer_processTransactionWithoutProcessBatchSlot(t *testing.T) {
+	timeoutCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		timeout     time.Duration
+		expectedErr error
+	}{
+		{
+			name:        "Slot timeout",
+			ctx:         context.Background(),
+			timeout:     10 * time.Millisecond,
+			expectedErr: ErrProcessBatchSlotTimeout,
+		},
+		{
+			name:        "Context cancelled",
+			ctx:         timeoutCtx,
+			timeout:     0,
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f := setupFinalizer(true)
+			limiter := newProcessBatchLimiter(executorMock, 1, tc.timeout)
+			f.processBatchLimiter = limiter
+			f.executor = limiter
+			// Take the only slot so the tx can't be sent to the executor
+			limiter.slots <- struct{}{}
+			tx := &TxTracker{
+				Hash:                          txHash,
+				From:                          senderAddr,
+				Nonce:                         nonce1,
+				BreakEvenGasPrice:             breakEvenGasPrice,
+				GasPrice:                      breakEvenGasPrice,
+				EffectiveGasPriceProcessCount: 1,
+			}
+			dbManagerMock.On("GetForkIDByBatchNumber", mock.Anything).Return(forkId5)
+			oldStateRoot := f.batch.stateRoot
+
+			// act
+			errWg, err := f.processTransaction(tc.ctx, tx)
+
+			// assert
+			assert.Nil(t, errWg)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			var slotErr *processBatchSlotError
+			assert.True(t, errors.As(err, &slotErr))
+			assert.Equal(t, oldStateRoot, f.batch.stateRoot)
+			workerMock.AssertNotCalled(t, "DeleteTx", mock.Anything, mock.Anything)
+			dbManagerMock.AssertNotCalled(t, "UpdateTxStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			executorMock.AssertNotCalled(t, "ProcessBatch", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFinalizer_processTransactionProcessBatchCache(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Successful response is served from the cache", func(t *testing.T) {
+		// arrange
+		f := setupFinalizer(true)
+		f.processBatchCache = newProcessBatchCache(16)
+		f.processRequest.OldStateRoot = oldHash
+		response := &state.ProcessBatchResponse{NewStateRoot: newHash, NewLocalExitRoot: newHash}
+		executorMock.On("ProcessBatch", ctx, mock.Anything, true).Return(response, nil).Once()
+
+		// act
+		_, err := f.processTransaction(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, newHash, f.batch.stateRoot)
+		// process the same request again
+		f.processRequest.OldStateRoot = oldHash
+		f.batch.stateRoot = oldHash
+		_, err = f.processTransaction(ctx, nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, newHash, f.batch.stateRoot)
+		executorMock.AssertNumberOfCalls(t, "ProcessBatch", 1)
+	})
+
+	t.Run("Executor level error is not cached", func(t *testing.T) {
+		// arrange
+		f := setupFinalizer(true)
+		f.processBatchCache = newProcessBatchCache(16)
+		f.processRequest.OldStateRoot = oldHash
+		tx := &TxTracker{
+			Hash:                          txHash,
+			From:                          senderAddr,
+			Nonce:                         nonce1,
+			BreakEvenGasPrice:             breakEvenGasPrice,
+			GasPrice:                      breakEvenGasPrice,
+			EffectiveGasPriceProcessCount: 1,
+		}
+		response := &state.ProcessBatchResponse{
+			NewStateRoot:         oldHash,
+			Responses:            []*state.ProcessTransactionResponse{{TxHash: txHash}},
+			IsExecutorLevelError: true,
+			ExecutorError:        runtime.ErrExecutorSMMainCountersOverflowSteps,
+		}
+		dbManagerMock.On("GetForkIDByBatchNumber", mock.Anything).Return(forkId5)
+		executorMock.On("ProcessBatch", ctx, mock.Anything, true).Return(response, nil).Twice()
+		workerMock.On("DeleteTx", tx.Hash, tx.From).Return().Twice()
+		dbManagerMock.On("UpdateTxStatus", ctx, tx.Hash, pool.TxStatusInvalid, false, mock.Anything).Return(nil).Twice()
+
+		// act
+		_, err := f.processTransaction(ctx, tx)
+		require.NoError(t, err)
+		_, err = f.processTransaction(ctx, tx)
+
+		// assert
+		require.NoError(t, err)
+		executorMock.AssertNumberOfCalls(t, "ProcessBatch", 2)
+		workerMock.AssertExpectations(t)
+		dbManagerMock.AssertExpectations(t)
+	})
+}
+
+func TestFinalizer_processTransactionExecutorContextError(t *testing.T) {
+	// arrange
+	f := setupFinalizer(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tx := &TxTracker{
+		Hash:                          txHash,
+		From:                          senderAddr,
+		Nonce:                         nonce1,
+		BreakEvenGasPrice:             breakEvenGasPrice,
+		GasPrice:                      breakEvenGasPrice,
+		EffectiveGasPriceProcessCount: 1,
+	}
+	dbManagerMock.On("GetForkIDByBatchNumber", mock.Anything).Return(forkId5)
+	executorMock.On("ProcessBatch", ctx, mock.Anything, true).Return(nil, context.Canceled).Once()
+
+	// act
+	errWg, err := f.processTransaction(ctx, tx)
+
+	// assert
+	assert.Nil(t, errWg)
+	assert.ErrorIs(t, err, context.Canceled)
+	var slotErr *processBatchSlotError
+	assert.False(t, errors.As(err, &slotErr))
+	workerMock.AssertNotCalled(t, "DeleteTx", mock.Anything, mock.Anything)
+	executorMock.AssertExpectations(t)
+}
+
 func Test_handleForcedTxsProcessResp(t *testing.T) {
 	var chainID = new(big.Int).SetInt64(400)
 	var pvtKey = "0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e"
@@ -2068,6 +2291,40 @@ func TestFinalizer_reprocessFullBatch(t *testing.T) {
 	}
 }
 
+func TestFinalizer_reprocessFullBatchWaitsForProcessBatchSlot(t *testing.T) {
+	// arrange
+	f := setupFinalizer(true)
+	limiter := newProcessBatchLimiter(executorMock, 1, time.Millisecond)
+	f.processBatchLimiter = limiter
+	f.executor = limiter
+	batch := &state.Batch{
+		BatchNumber:    1,
+		BatchL2Data:    decodedBatchL2Data,
+		GlobalExitRoot: oldHash,
+		Timestamp:      time.Now(),
+	}
+	result := &state.ProcessBatchResponse{NewStateRoot: newHash}
+	dbManagerMock.On("GetBatchByNumber", context.Background(), batch.BatchNumber, nil).Return(batch, nil).Once()
+	dbManagerMock.On("GetForkIDByBatchNumber", batch.BatchNumber).Return(uint64(5)).Once()
+	executorMock.On("ProcessBatch", context.Background(), mock.Anything, false).Return(result, nil).Once()
+	// Keep the only slot taken for longer than the slot timeout
+	limiter.slots <- struct{}{}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		<-limiter.slots
+	}()
+
+	// act
+	response, err := f.reprocessFullBatch(context.Background(), batch.BatchNumber, f.batch.initialStateRoot, newHash)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, result, response)
+	assert.False(t, f.reprocessFullBatchError.Load())
+	dbManagerMock.AssertExpectations(t)
+	executorMock.AssertExpectations(t)
+}
+
 func TestFinalizer_getLastBatchNumAndOldStateRoot(t *testing.T) {
 	f := setupFinalizer(false)
 	testCases := []struct {
@@ -2492,6 +2749,7 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 			localExitRoot:      newHash,
 			timestamp:          now(),
 			globalExitRoot:     oldHash,
+			initialResources:   getMaxRemainingResources(bc),
 			remainingResources: getMaxRemainingResources(bc),
 			closingReason:      state.EmptyClosingReason,
 		}
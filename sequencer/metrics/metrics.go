@@ -28,8 +28,16 @@ const (
 	WorkerPrefix = Prefix + "worker_"
 	// WorkerProcessingTimeName is the name of the metric that shows the worker processing time.
 	WorkerProcessingTimeName = WorkerPrefix + "processing_time"
+	// ProcessBatchCacheHitsName is the name of the metric that counts the ProcessBatch responses served from the cache.
+	ProcessBatchCacheHitsName = Prefix + "process_batch_cache_hits"
+	// ProcessBatchCacheMissesName is the name of the metric that counts the ProcessBatch requests not found in the cache.
+	ProcessBatchCacheMissesName = Prefix + "process_batch_cache_misses"
+	// BatchResourcesUtilizationName is the name of the metric that shows the percentage of each resource used by the closed batches.
+	BatchResourcesUtilizationName = Prefix + "batch_resources_utilization"
 	// TxProcessedLabelName is the name of the label for the processed transactions.
 	TxProcessedLabelName = "status"
+	// ResourceLabelName is the name of the label for the batch resource.
+	ResourceLabelName = "resource"
 )
 
 // TxProcessedLabel represents the possible values for the
@@ -48,10 +56,11 @@ const (
 // Register the metrics for the sequencer package.
 func Register() {
 	var (
-		counters    []prometheus.CounterOpts
-		counterVecs []metrics.CounterVecOpts
-		gauges      []prometheus.GaugeOpts
-		histograms  []prometheus.HistogramOpts
+		counters      []prometheus.CounterOpts
+		counterVecs   []metrics.CounterVecOpts
+		gauges        []prometheus.GaugeOpts
+		histograms    []prometheus.HistogramOpts
+		histogramVecs []metrics.HistogramVecOpts
 	)
 
 	counters = []prometheus.CounterOpts{
@@ -63,6 +72,14 @@ func Register() {
 			Name: SequencesOversizedDataErrorName,
 			Help: "[SEQUENCER] total count of sequences with oversized data error",
 		},
+		{
+			Name: ProcessBatchCacheHitsName,
+			Help: "[SEQUENCER] total count of ProcessBatch responses served from the cache",
+		},
+		{
+			Name: ProcessBatchCacheMissesName,
+			Help: "[SEQUENCER] total count of ProcessBatch requests not found in the cache",
+		},
 	}
 
 	counterVecs = []metrics.CounterVecOpts{
@@ -101,10 +118,22 @@ func Register() {
 		},
 	}
 
+	histogramVecs = []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    BatchResourcesUtilizationName,
+				Help:    "[SEQUENCER] percentage of each batch resource used when the batch is closed",
+				Buckets: prometheus.LinearBuckets(10, 10, 10), //nolint:gomnd
+			},
+			Labels: []string{ResourceLabelName},
+		},
+	}
+
 	metrics.RegisterCounters(counters...)
 	metrics.RegisterCounterVecs(counterVecs...)
 	metrics.RegisterGauges(gauges...)
 	metrics.RegisterHistograms(histograms...)
+	metrics.RegisterHistogramVecs(histogramVecs...)
 }
 
 // AverageGasPrice sets the gauge to the given average gas price.
@@ -151,3 +180,22 @@ func WorkerProcessingTime(lastProcessTime time.Duration) {
 	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
 	metrics.HistogramObserve(WorkerProcessingTimeName, execTimeInSeconds)
 }
+
+// ProcessBatchCacheHit increases the counter of ProcessBatch cache hits.
+func ProcessBatchCacheHit() {
+	metrics.CounterInc(ProcessBatchCacheHitsName)
+}
+
+// ProcessBatchCacheMiss increases the counter of ProcessBatch cache misses.
+func ProcessBatchCacheMiss() {
+	metrics.CounterInc(ProcessBatchCacheMissesName)
+}
+
+// BatchResourcesUtilization observes, for each resource of a closed batch, the percentage used
+// given the percentage that was still remaining when the batch was closed. Resources with a zero
+// max are not part of remainingPercent, so they are not observed.
+func BatchResourcesUtilization(remainingPercent map[string]float64) {
+	for resource, remaining := range remainingPercent {
+		metrics.HistogramVecObserve(BatchResourcesUtilizationName, resource, 100-remaining) //nolint:gomnd
+	}
+}
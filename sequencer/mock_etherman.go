@@ -105,23 +105,23 @@ func (_m *EthermanMock) GetLastBatchTimestamp() (uint64, error) {
 	return r0, r1
 }
 
-// GetLatestBatchNumber provides a mock function with given fields:
-func (_m *EthermanMock) GetLatestBatchNumber() (uint64, error) {
-	ret := _m.Called()
+// GetLatestBatchNumber provides a mock function with given fields: ctx
+func (_m *EthermanMock) GetLatestBatchNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
 
 	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,6 +184,58 @@ func (_m *StateMock) GetBalanceByStateRoot(ctx context.Context, address common.A
 	return r0, r1
 }
 
+// GetBalances provides a mock function with given fields: ctx, addresses, root
+func (_m *StateMock) GetBalances(ctx context.Context, addresses []common.Address, root common.Hash) (map[common.Address]*big.Int, error) {
+	ret := _m.Called(ctx, addresses, root)
+
+	var r0 map[common.Address]*big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Address, common.Hash) (map[common.Address]*big.Int, error)); ok {
+		return rf(ctx, addresses, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Address, common.Hash) map[common.Address]*big.Int); ok {
+		r0 = rf(ctx, addresses, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address]*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Address, common.Hash) error); ok {
+		r1 = rf(ctx, addresses, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatchByL2BlockNumber provides a mock function with given fields: ctx, l2BlockNumber, dbTx
+func (_m *StateMock) GetBatchByL2BlockNumber(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	ret := _m.Called(ctx, l2BlockNumber, dbTx)
+
+	var r0 *state.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
+		return rf(ctx, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
+		r0 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBatchByNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -642,6 +694,32 @@ func (_m *StateMock) GetNonceByStateRoot(ctx context.Context, address common.Add
 	return r0, r1
 }
 
+// GetNonces provides a mock function with given fields: ctx, addresses, root
+func (_m *StateMock) GetNonces(ctx context.Context, addresses []common.Address, root common.Hash) (map[common.Address]*big.Int, error) {
+	ret := _m.Called(ctx, addresses, root)
+
+	var r0 map[common.Address]*big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Address, common.Hash) (map[common.Address]*big.Int, error)); ok {
+		return rf(ctx, addresses, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Address, common.Hash) map[common.Address]*big.Int); ok {
+		r0 = rf(ctx, addresses, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address]*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Address, common.Hash) error); ok {
+		r1 = rf(ctx, addresses, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStoredFlushID provides a mock function with given fields: ctx
 func (_m *StateMock) GetStoredFlushID(ctx context.Context) (uint64, string, error) {
 	ret := _m.Called(ctx)
@@ -732,6 +810,32 @@ func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumb
 	return r0, r1, r2
 }
 
+// GetTransactionsByBatchNumbers provides a mock function with given fields: ctx, batchNumbers, dbTx
+func (_m *StateMock) GetTransactionsByBatchNumbers(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) (map[uint64][]types.Transaction, error) {
+	ret := _m.Called(ctx, batchNumbers, dbTx)
+
+	var r0 map[uint64][]types.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64, pgx.Tx) (map[uint64][]types.Transaction, error)); ok {
+		return rf(ctx, batchNumbers, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64, pgx.Tx) map[uint64][]types.Transaction); ok {
+		r0 = rf(ctx, batchNumbers, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uint64][]types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumbers, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxsOlderThanNL1Blocks provides a mock function with given fields: ctx, nL1Blocks, dbTx
 func (_m *StateMock) GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks uint64, dbTx pgx.Tx) ([]common.Hash, error) {
 	ret := _m.Called(ctx, nL1Blocks, dbTx)
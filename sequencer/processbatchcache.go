@@ -0,0 +1,105 @@
+package sequencer
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// processBatchCache is a LRU cache of ProcessBatch responses keyed by the hash of the ProcessRequest content.
+// All the cached entries share the same OldStateRoot, the cache is purged when a request with a different one arrives.
+type processBatchCache struct {
+	size         int
+	oldStateRoot common.Hash
+	entries      map[common.Hash]*list.Element
+	lru          *list.List
+	mux          sync.Mutex
+}
+
+type processBatchCacheEntry struct {
+	key      common.Hash
+	response *state.ProcessBatchResponse
+}
+
+// newProcessBatchCache creates a new processBatchCache that keeps at most size entries
+func newProcessBatchCache(size int) *processBatchCache {
+	return &processBatchCache{
+		size:    size,
+		entries: make(map[common.Hash]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// processRequestKey returns the cache key for the given request
+func processRequestKey(request state.ProcessRequest) common.Hash {
+	var batchNumber, timestamp [8]byte
+	binary.BigEndian.PutUint64(batchNumber[:], request.BatchNumber)
+	binary.BigEndian.PutUint64(timestamp[:], uint64(request.Timestamp.Unix()))
+	return crypto.Keccak256Hash(
+		batchNumber[:],
+		request.OldStateRoot.Bytes(),
+		request.OldAccInputHash.Bytes(),
+		request.GlobalExitRoot.Bytes(),
+		request.Coinbase.Bytes(),
+		timestamp[:],
+		request.Transactions,
+	)
+}
+
+// get returns the cached response for the request, if any
+func (c *processBatchCache) get(request state.ProcessRequest) (*state.ProcessBatchResponse, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.invalidateIfStateRootChanged(request.OldStateRoot)
+	if elem, ok := c.entries[processRequestKey(request)]; ok {
+		c.lru.MoveToFront(elem)
+		metrics.ProcessBatchCacheHit()
+		return elem.Value.(*processBatchCacheEntry).response, true
+	}
+	metrics.ProcessBatchCacheMiss()
+	return nil, false
+}
+
+// put stores the response for the request, evicting the least recently used entry if the cache is full
+func (c *processBatchCache) put(request state.ProcessRequest, response *state.ProcessBatchResponse) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.invalidateIfStateRootChanged(request.OldStateRoot)
+	key := processRequestKey(request)
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*processBatchCacheEntry).response = response
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&processBatchCacheEntry{key: key, response: response})
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*processBatchCacheEntry).key)
+	}
+}
+
+// len returns the number of cached entries
+func (c *processBatchCache) len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.lru.Len()
+}
+
+// invalidateIfStateRootChanged purges the cache when the old state root differs from the cached one.
+// It must be called with the mutex held
+func (c *processBatchCache) invalidateIfStateRootChanged(oldStateRoot common.Hash) {
+	if c.oldStateRoot == oldStateRoot {
+		return
+	}
+	c.oldStateRoot = oldStateRoot
+	c.entries = make(map[common.Hash]*list.Element, c.size)
+	c.lru.Init()
+}
@@ -0,0 +1,84 @@
+package sequencer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProcessRequest(batchNumber uint64, oldStateRoot common.Hash, txs []byte) state.ProcessRequest {
+	return state.ProcessRequest{
+		BatchNumber:  batchNumber,
+		OldStateRoot: oldStateRoot,
+		Transactions: txs,
+		Coinbase:     common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D"),
+		Timestamp:    time.Unix(1690000000, 0),
+	}
+}
+
+func TestProcessBatchCacheHitAndMiss(t *testing.T) {
+	cache := newProcessBatchCache(2)
+	root := common.HexToHash("0x1")
+	request := newTestProcessRequest(1, root, []byte{0x01})
+	response := &state.ProcessBatchResponse{NewStateRoot: common.HexToHash("0x2")}
+
+	_, found := cache.get(request)
+	assert.False(t, found)
+
+	cache.put(request, response)
+	cached, found := cache.get(request)
+	require.True(t, found)
+	assert.Equal(t, response, cached)
+
+	// Same batch and root but different txs must not hit
+	_, found = cache.get(newTestProcessRequest(1, root, []byte{0x02}))
+	assert.False(t, found)
+
+	// Different timestamp must not hit
+	otherTimestamp := request
+	otherTimestamp.Timestamp = request.Timestamp.Add(time.Second)
+	_, found = cache.get(otherTimestamp)
+	assert.False(t, found)
+}
+
+func TestProcessBatchCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newProcessBatchCache(2)
+	root := common.HexToHash("0x1")
+	request1 := newTestProcessRequest(1, root, []byte{0x01})
+	request2 := newTestProcessRequest(1, root, []byte{0x02})
+	request3 := newTestProcessRequest(1, root, []byte{0x03})
+
+	cache.put(request1, &state.ProcessBatchResponse{})
+	cache.put(request2, &state.ProcessBatchResponse{})
+	// request1 becomes the most recently used, so request2 is evicted
+	_, found := cache.get(request1)
+	require.True(t, found)
+	cache.put(request3, &state.ProcessBatchResponse{})
+
+	assert.Equal(t, 2, cache.len())
+	_, found = cache.get(request2)
+	assert.False(t, found)
+	_, found = cache.get(request1)
+	assert.True(t, found)
+	_, found = cache.get(request3)
+	assert.True(t, found)
+}
+
+func TestProcessBatchCacheInvalidatedOnStateRootChange(t *testing.T) {
+	cache := newProcessBatchCache(2)
+	request := newTestProcessRequest(1, common.HexToHash("0x1"), []byte{0x01})
+	cache.put(request, &state.ProcessBatchResponse{})
+	require.Equal(t, 1, cache.len())
+
+	_, found := cache.get(newTestProcessRequest(1, common.HexToHash("0x2"), []byte{0x01}))
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.len())
+
+	// The previous entry is gone even if the old state root goes back
+	_, found = cache.get(request)
+	assert.False(t, found)
+}
@@ -0,0 +1,75 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// processBatchLimiter wraps a stateInterface to bound the number of ProcessBatch calls that the sequencer sends
+// concurrently to the executor. The rest of the methods are served directly by the wrapped stateInterface
+type processBatchLimiter struct {
+	stateInterface
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// newProcessBatchLimiter creates a new processBatchLimiter that allows at most maxConcurrency ProcessBatch calls at
+// the same time. A call waits at most timeout for a free slot, a zero timeout means it waits until the context is done
+func newProcessBatchLimiter(executor stateInterface, maxConcurrency int, timeout time.Duration) *processBatchLimiter {
+	return &processBatchLimiter{
+		stateInterface: executor,
+		slots:          make(chan struct{}, maxConcurrency),
+		timeout:        timeout,
+	}
+}
+
+// ProcessBatch calls the executor ProcessBatch once a slot is available. If no slot gets free within the timeout,
+// or the context is done while waiting, it returns a *processBatchSlotError wrapping ErrProcessBatchSlotTimeout
+// or the context error
+func (l *processBatchLimiter) ProcessBatch(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
+	return l.processBatch(ctx, request, updateMerkleTree, l.timeout)
+}
+
+// ProcessBatchWithoutTimeout calls the executor ProcessBatch once a slot is available, waiting for it until the
+// context is done regardless of the configured timeout. It's used by the calls that can't be retried later
+func (l *processBatchLimiter) ProcessBatchWithoutTimeout(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error) {
+	return l.processBatch(ctx, request, updateMerkleTree, 0)
+}
+
+func (l *processBatchLimiter) processBatch(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool, timeout time.Duration) (*state.ProcessBatchResponse, error) {
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, &processBatchSlotError{err: ctx.Err()}
+	case <-timeoutCh:
+		return nil, &processBatchSlotError{err: ErrProcessBatchSlotTimeout}
+	}
+	defer func() { <-l.slots }()
+
+	return l.stateInterface.ProcessBatch(ctx, request, updateMerkleTree)
+}
+
+// processBatchSlotError is returned by processBatchLimiter when a ProcessBatch call doesn't get a free slot, so the
+// request is known not to have reached the executor. It wraps ErrProcessBatchSlotTimeout or the context error
+type processBatchSlotError struct {
+	err error
+}
+
+// Error returns the error message
+func (e *processBatchSlotError) Error() string {
+	return "failed to get a free ProcessBatch slot: " + e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *processBatchSlotError) Unwrap() error {
+	return e.err
+}
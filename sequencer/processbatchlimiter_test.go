@@ -0,0 +1,126 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlockingStateMock(t *testing.T, started chan<- struct{}, release <-chan struct{}) *StateMock {
+	stateMock := NewStateMock(t)
+	stateMock.On("ProcessBatch", mock.Anything, mock.Anything, false).Run(func(args mock.Arguments) {
+		started <- struct{}{}
+		<-release
+	}).Return(&state.ProcessBatchResponse{}, nil)
+	return stateMock
+}
+
+func TestProcessBatchLimiterTimeout(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	limiter := newProcessBatchLimiter(newBlockingStateMock(t, started, release), 1, 50*time.Millisecond)
+	ctx := context.Background()
+
+	errCh := make(chan error)
+	go func() {
+		_, err := limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: 1}, false)
+		errCh <- err
+	}()
+	<-started
+
+	// The only slot is taken, so the second call times out without reaching the executor
+	_, err := limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: 2}, false)
+	assert.ErrorIs(t, err, ErrProcessBatchSlotTimeout)
+
+	close(release)
+	require.NoError(t, <-errCh)
+
+	// Once the slot is released the next call is sent to the executor
+	go func() { <-started }()
+	_, err = limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: 3}, false)
+	assert.NoError(t, err)
+}
+
+func TestProcessBatchLimiterContextCancelled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	limiter := newProcessBatchLimiter(newBlockingStateMock(t, started, release), 1, 0)
+
+	errCh := make(chan error)
+	go func() {
+		_, err := limiter.ProcessBatch(context.Background(), state.ProcessRequest{BatchNumber: 1}, false)
+		errCh <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	_, err := limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: 2}, false)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-errCh)
+}
+
+func TestProcessBatchLimiterMaxConcurrency(t *testing.T) {
+	const maxConcurrency = 2
+	started := make(chan struct{}, maxConcurrency+1)
+	release := make(chan struct{})
+	limiter := newProcessBatchLimiter(newBlockingStateMock(t, started, release), maxConcurrency, 0)
+	ctx := context.Background()
+
+	errCh := make(chan error)
+	for i := 0; i < maxConcurrency+1; i++ {
+		go func(batchNumber uint64) {
+			_, err := limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: batchNumber}, false)
+			errCh <- err
+		}(uint64(i))
+	}
+
+	for i := 0; i < maxConcurrency; i++ {
+		<-started
+	}
+	// The last call must be waiting for a free slot
+	select {
+	case <-started:
+		t.Fatal("more ProcessBatch calls than allowed reached the executor")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	for i := 0; i < maxConcurrency+1; i++ {
+		require.NoError(t, <-errCh)
+	}
+}
+
+func TestProcessBatchLimiterWithoutTimeout(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	limiter := newProcessBatchLimiter(newBlockingStateMock(t, started, release), 1, time.Millisecond)
+	ctx := context.Background()
+
+	errCh := make(chan error)
+	go func() {
+		_, err := limiter.ProcessBatch(ctx, state.ProcessRequest{BatchNumber: 1}, false)
+		errCh <- err
+	}()
+	<-started
+
+	// The slot is released after the timeout, the call keeps waiting for it instead of failing
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		<-started
+	}()
+	_, err := limiter.ProcessBatchWithoutTimeout(ctx, state.ProcessRequest{BatchNumber: 2}, false)
+	assert.NoError(t, err)
+	require.NoError(t, <-errCh)
+}
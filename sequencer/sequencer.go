@@ -156,6 +156,7 @@ func (s *Sequencer) bootstrap(ctx context.Context, dbManager *dbManager, finaliz
 			batchNumber:        processingCtx.BatchNumber,
 			coinbase:           processingCtx.Coinbase,
 			timestamp:          timestamp,
+			initialResources:   getMaxRemainingResources(finalizer.batchConstraints),
 			remainingResources: getMaxRemainingResources(finalizer.batchConstraints),
 		}
 	} else {
@@ -213,7 +214,7 @@ func (s *Sequencer) isSynced(ctx context.Context) bool {
 	if lastBatchNum > lastSyncedBatchNum {
 		return true
 	}
-	lastEthBatchNum, err := s.etherman.GetLatestBatchNumber()
+	lastEthBatchNum, err := s.etherman.GetLatestBatchNumber(ctx)
 	if err != nil {
 		log.Errorf("failed to get last eth batch, err: %v", err)
 		return false
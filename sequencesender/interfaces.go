@@ -21,7 +21,7 @@ type etherman interface {
 	EstimateGasSequenceBatches(sender common.Address, sequences []ethmanTypes.Sequence, l2Coinbase common.Address) (*types.Transaction, error)
 	GetLastBatchTimestamp() (uint64, error)
 	GetLatestBlockTimestamp(ctx context.Context) (uint64, error)
-	GetLatestBatchNumber() (uint64, error)
+	GetLatestBatchNumber(ctx context.Context) (uint64, error)
 }
 
 // stateInterface gathers the methods required to interact with the state.
@@ -323,7 +323,7 @@ func (s *SequenceSender) isSynced(ctx context.Context) bool {
 	if lastBatchNum > lastSyncedBatchNum {
 		return true
 	}
-	lastEthBatchNum, err := s.etherman.GetLatestBatchNumber()
+	lastEthBatchNum, err := s.etherman.GetLatestBatchNumber(ctx)
 	if err != nil {
 		log.Errorf("failed to get last eth batch, err: %v", err)
 		return false
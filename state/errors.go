@@ -57,6 +57,11 @@ var (
 	ErrInvalidData = errors.New("invalid data")
 	// ErrBatchResourceBytesUnderflow happens when the batch runs out of Bytes
 	ErrBatchResourceBytesUnderflow = NewBatchRemainingResourcesUnderflowError(nil, "Bytes")
+	// ErrBatchRemainingResourcesUnderflow happens when the batch runs out of any of its resources
+	ErrBatchRemainingResourcesUnderflow = errors.New("underflow of remaining resources for current batch")
+
+	// ErrBatchRemainingResourcesOverflow happens when the remaining resources of a batch would exceed the max
+	ErrBatchRemainingResourcesOverflow = errors.New("overflow of remaining resources for current batch")
 
 	zkCounterErrPrefix = "ZKCounter: "
 )
@@ -75,6 +80,23 @@ func GetZKCounterError(name string) error {
 	return errors.New(zkCounterErrPrefix + name)
 }
 
+// ResourceUnderflowError happens when a resource (Bytes or a ZKCounter) required is greater than the remaining
+type ResourceUnderflowError struct {
+	ResourceName string
+	Remaining    uint64
+	Required     uint64
+}
+
+// Error returns the error message
+func (e *ResourceUnderflowError) Error() string {
+	return fmt.Sprintf("underflow of resource %s: required %d, remaining %d", e.ResourceName, e.Required, e.Remaining)
+}
+
+// Underflow returns the amount of the resource missing to fit the required one
+func (e *ResourceUnderflowError) Underflow() uint64 {
+	return e.Required - e.Remaining
+}
+
 // BatchRemainingResourcesUnderflowError happens when the execution of a batch runs out of counters
 type BatchRemainingResourcesUnderflowError struct {
 	Message      string
@@ -88,6 +110,22 @@ func (b BatchRemainingResourcesUnderflowError) Error() string {
 	return constructErrorMsg(b.ResourceName)
 }
 
+// Unwrap returns the underlying error, which is a *ResourceUnderflowError when the resource amounts are known
+func (b BatchRemainingResourcesUnderflowError) Unwrap() error {
+	return b.Err
+}
+
+// Is reports whether target is ErrBatchRemainingResourcesUnderflow, which matches any underflow, or a
+// BatchRemainingResourcesUnderflowError for the same resource, so errors.Is(err, ErrBatchResourceBytesUnderflow)
+// matches any bytes underflow
+func (b BatchRemainingResourcesUnderflowError) Is(target error) bool {
+	if target == ErrBatchRemainingResourcesUnderflow {
+		return true
+	}
+	t, ok := target.(*BatchRemainingResourcesUnderflowError)
+	return ok && t.ResourceName == b.ResourceName
+}
+
 // NewBatchRemainingResourcesUnderflowError creates a new BatchRemainingResourcesUnderflowError
 func NewBatchRemainingResourcesUnderflowError(err error, resourceName string) error {
 	return &BatchRemainingResourcesUnderflowError{
@@ -101,3 +139,9 @@ func NewBatchRemainingResourcesUnderflowError(err error, resourceName string) er
 func constructErrorMsg(resourceName string) string {
 	return fmt.Sprintf("underflow of remaining resources for current batch. Resource %s", resourceName)
 }
+
+// NewBatchRemainingResourcesOverflowError creates the error returned when giving back resources to a batch
+// would exceed the max of the given resource
+func NewBatchRemainingResourcesOverflowError(resourceName string) error {
+	return fmt.Errorf("%w. Resource %s", ErrBatchRemainingResourcesOverflow, resourceName)
+}
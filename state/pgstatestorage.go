@@ -866,6 +866,39 @@ func (p *PostgresStorage) GetTransactionsByBatchNumber(ctx context.Context, batc
 	return txs, effectivePercentages, nil
 }
 
+// GetTransactionsByBatchNumbers returns the transactions of the given batches grouped by batch number,
+// keeping the order of the transactions inside each batch. Batches without transactions are not included.
+func (p *PostgresStorage) GetTransactionsByBatchNumbers(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) (map[uint64][]types.Transaction, error) {
+	const getTransactionsByBatchNumbersSQL = "SELECT b.batch_num, t.encoded FROM state.transaction t INNER JOIN state.l2block b ON t.l2_block_num = b.block_num WHERE b.batch_num = ANY($1) ORDER BY b.batch_num ASC, t.l2_block_num ASC"
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getTransactionsByBatchNumbersSQL, batchNumbers)
+	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make(map[uint64][]types.Transaction, len(batchNumbers))
+	for rows.Next() {
+		var (
+			batchNumber uint64
+			encoded     string
+		)
+		err := rows.Scan(&batchNumber, &encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := DecodeTx(encoded)
+		if err != nil {
+			return nil, err
+		}
+		txs[batchNumber] = append(txs[batchNumber], *tx)
+	}
+
+	return txs, nil
+}
+
 // GetTxsHashesByBatchNumber returns the hashes of the transactions in the
 // given batch.
 func (p *PostgresStorage) GetTxsHashesByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (encoded []common.Hash, err error) {
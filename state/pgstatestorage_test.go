@@ -90,6 +90,68 @@ func TestGetBatchByL2BlockNumber(t *testing.T) {
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestGetTransactionsByBatchNumbers(t *testing.T) {
+	initOrResetDB()
+	setup()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	err = testState.AddBlock(ctx, block, dbTx)
+	assert.NoError(t, err)
+
+	for _, batchNumber := range []uint64{1, 2, 3} {
+		_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num) VALUES ($1)", batchNumber)
+		require.NoError(t, err)
+	}
+
+	// Batch 1 has two L2 blocks, batch 2 has one and batch 3 is empty
+	l2BlocksBatch := []uint64{1, 1, 2}
+	txsByBatch := map[uint64][]common.Hash{}
+	for i, batchNumber := range l2BlocksBatch {
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       nil,
+			Value:    new(big.Int),
+			Gas:      0,
+			GasPrice: big.NewInt(0),
+		})
+		receipt := &types.Receipt{
+			Type:              uint8(tx.Type()),
+			PostState:         state.ZeroHash.Bytes(),
+			EffectiveGasPrice: big.NewInt(0),
+			BlockNumber:       big.NewInt(int64(i + 1)),
+			TxHash:            tx.Hash(),
+			Status:            types.ReceiptStatusSuccessful,
+		}
+		header := &types.Header{
+			Number:     big.NewInt(int64(i + 1)),
+			ParentHash: state.ZeroHash,
+			Coinbase:   state.ZeroAddress,
+			Root:       state.ZeroHash,
+			GasLimit:   10,
+			Time:       uint64(time.Now().Unix()),
+		}
+		receipts := []*types.Receipt{receipt}
+		l2Block := types.NewBlock(header, []*types.Transaction{tx}, []*types.Header{}, receipts, &trie.StackTrie{})
+		receipt.BlockHash = l2Block.Hash()
+
+		err = pgStateStorage.AddL2Block(ctx, batchNumber, l2Block, receipts, state.MaxEffectivePercentage, dbTx)
+		require.NoError(t, err)
+		txsByBatch[batchNumber] = append(txsByBatch[batchNumber], tx.Hash())
+	}
+
+	txs, err := pgStateStorage.GetTransactionsByBatchNumbers(ctx, []uint64{1, 2, 3}, dbTx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(txs))
+	for batchNumber, hashes := range txsByBatch {
+		require.Equal(t, len(hashes), len(txs[batchNumber]))
+		for i, hash := range hashes {
+			assert.Equal(t, hash, txs[batchNumber][i].Hash())
+		}
+	}
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
 func TestAddAndGetSequences(t *testing.T) {
 	initOrResetDB()
 
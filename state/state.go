@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/jackc/pgx/v4"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -22,6 +23,10 @@ var (
 	ZeroAddress = common.Address{}
 )
 
+// maxConcurrentAccountReads is the maximum number of requests sent at the same time to the MT Service
+// by GetBalances and GetNonces
+const maxConcurrentAccountReads = 16
+
 // State is an implementation of the state
 type State struct {
 	cfg Config
@@ -129,6 +134,51 @@ func (s *State) GetNonceByStateRoot(ctx context.Context, address common.Address,
 	return s.tree.GetNonce(ctx, address, root.Bytes())
 }
 
+// GetBalances gets the balances of the given addresses from the MT Service using the provided state root.
+// The MT Service has no multi-key read, so it's not a single query: the balances are requested one by one,
+// with at most maxConcurrentAccountReads requests at the same time
+func (s *State) GetBalances(ctx context.Context, addresses []common.Address, root common.Hash) (map[common.Address]*big.Int, error) {
+	return s.getAccountsValue(ctx, addresses, root, s.GetBalanceByStateRoot)
+}
+
+// GetNonces gets the nonces of the given addresses from the MT Service using the provided state root.
+// The MT Service has no multi-key read, so it's not a single query: the nonces are requested one by one,
+// with at most maxConcurrentAccountReads requests at the same time
+func (s *State) GetNonces(ctx context.Context, addresses []common.Address, root common.Hash) (map[common.Address]*big.Int, error) {
+	return s.getAccountsValue(ctx, addresses, root, s.GetNonceByStateRoot)
+}
+
+// getAccountsValue calls getValue for each one of the addresses, running at most maxConcurrentAccountReads calls
+// at the same time, and returns the values by address
+func (s *State) getAccountsValue(ctx context.Context, addresses []common.Address, root common.Hash,
+	getValue func(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error)) (map[common.Address]*big.Int, error) {
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+
+	values := make(map[common.Address]*big.Int, len(addresses))
+	var valuesMux sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentAccountReads)
+	for _, address := range addresses {
+		address := address
+		g.Go(func() error {
+			value, err := getValue(gCtx, address, root)
+			if err != nil {
+				return err
+			}
+			valuesMux.Lock()
+			values[address] = value
+			valuesMux.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // GetTree returns State inner tree
 func (s *State) GetTree() *merkletree.StateTree {
 	return s.tree
@@ -726,6 +726,19 @@ func TestGenesis(t *testing.T) {
 		}
 	}
 
+	addresses := []common.Address{
+		common.HexToAddress("0xb1D0Dc8E2Ce3a93EB2b32f4C7c3fD9dDAf1211FA"),
+		common.HexToAddress("0xb1D0Dc8E2Ce3a93EB2b32f4C7c3fD9dDAf1211FB"),
+	}
+	balances, err := testState.GetBalances(ctx, addresses, common.BytesToHash(stateRoot))
+	require.NoError(t, err)
+	require.Equal(t, "1000", balances[addresses[0]].String())
+	require.Equal(t, "2000", balances[addresses[1]].String())
+	nonces, err := testState.GetNonces(ctx, addresses, common.BytesToHash(stateRoot))
+	require.NoError(t, err)
+	require.Equal(t, "1", nonces[addresses[0]].String())
+	require.Equal(t, "1", nonces[addresses[1]].String())
+
 	err = testState.GetTree().Flush(ctx, "")
 	require.NoError(t, err)
 }
@@ -2,7 +2,7 @@ package state
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"math/big"
 	"strings"
 	"time"
@@ -104,32 +104,33 @@ func (z *ZKCounters) SumUp(other ZKCounters) {
 	z.UsedSteps += other.UsedSteps
 }
 
-// Sub subtract zk counters with passed zk counters (not safe)
+// Sub subtract zk counters with passed zk counters (not safe).
+// When a counter underflows it returns a *ResourceUnderflowError naming it
 func (z *ZKCounters) Sub(other ZKCounters) error {
 	// ZKCounters
 	if other.CumulativeGasUsed > z.CumulativeGasUsed {
-		return GetZKCounterError("CumulativeGasUsed")
+		return newResourceUnderflowError("CumulativeGasUsed", z.CumulativeGasUsed, other.CumulativeGasUsed)
 	}
 	if other.UsedKeccakHashes > z.UsedKeccakHashes {
-		return GetZKCounterError("UsedKeccakHashes")
+		return newResourceUnderflowError("UsedKeccakHashes", uint64(z.UsedKeccakHashes), uint64(other.UsedKeccakHashes))
 	}
 	if other.UsedPoseidonHashes > z.UsedPoseidonHashes {
-		return GetZKCounterError("UsedPoseidonHashes")
+		return newResourceUnderflowError("UsedPoseidonHashes", uint64(z.UsedPoseidonHashes), uint64(other.UsedPoseidonHashes))
 	}
 	if other.UsedPoseidonPaddings > z.UsedPoseidonPaddings {
-		return fmt.Errorf("underflow ZKCounter: UsedPoseidonPaddings")
+		return newResourceUnderflowError("UsedPoseidonPaddings", uint64(z.UsedPoseidonPaddings), uint64(other.UsedPoseidonPaddings))
 	}
 	if other.UsedMemAligns > z.UsedMemAligns {
-		return GetZKCounterError("UsedMemAligns")
+		return newResourceUnderflowError("UsedMemAligns", uint64(z.UsedMemAligns), uint64(other.UsedMemAligns))
 	}
 	if other.UsedArithmetics > z.UsedArithmetics {
-		return GetZKCounterError("UsedArithmetics")
+		return newResourceUnderflowError("UsedArithmetics", uint64(z.UsedArithmetics), uint64(other.UsedArithmetics))
 	}
 	if other.UsedBinaries > z.UsedBinaries {
-		return GetZKCounterError("UsedBinaries")
+		return newResourceUnderflowError("UsedBinaries", uint64(z.UsedBinaries), uint64(other.UsedBinaries))
 	}
 	if other.UsedSteps > z.UsedSteps {
-		return GetZKCounterError("UsedSteps")
+		return newResourceUnderflowError("UsedSteps", uint64(z.UsedSteps), uint64(other.UsedSteps))
 	}
 
 	z.CumulativeGasUsed -= other.CumulativeGasUsed
@@ -144,27 +145,99 @@ func (z *ZKCounters) Sub(other ZKCounters) error {
 	return nil
 }
 
+func newResourceUnderflowError(resourceName string, remaining, required uint64) error {
+	return &ResourceUnderflowError{ResourceName: resourceName, Remaining: remaining, Required: required}
+}
+
 // BatchResources is a struct that contains the ZKEVM resources used by a batch/tx
 type BatchResources struct {
 	ZKCounters ZKCounters
 	Bytes      uint64
 }
 
-// Sub subtracts the batch resources from other
+// Sub subtracts the batch resources from other.
+// On underflow it returns a *BatchRemainingResourcesUnderflowError wrapping a *ResourceUnderflowError
+// with the exhausted resource and the amounts. The receiver is only modified if all the resources fit
 func (r *BatchResources) Sub(other BatchResources) error {
 	// Bytes
 	if other.Bytes > r.Bytes {
-		return ErrBatchResourceBytesUnderflow
+		return NewBatchRemainingResourcesUnderflowError(newResourceUnderflowError("Bytes", r.Bytes, other.Bytes), "Bytes")
 	}
-	bytesBackup := r.Bytes
-	r.Bytes -= other.Bytes
-	err := r.ZKCounters.Sub(other.ZKCounters)
+	// ZKCounters
+	zkCounters := r.ZKCounters
+	err := zkCounters.Sub(other.ZKCounters)
 	if err != nil {
-		r.Bytes = bytesBackup
-		return NewBatchRemainingResourcesUnderflowError(err, err.Error())
+		resourceName := err.Error()
+		var underflowErr *ResourceUnderflowError
+		if errors.As(err, &underflowErr) {
+			resourceName = underflowErr.ResourceName
+		}
+		return NewBatchRemainingResourcesUnderflowError(err, zkCounterErrPrefix+resourceName)
+	}
+
+	r.Bytes -= other.Bytes
+	r.ZKCounters = zkCounters
+
+	return nil
+}
+
+// Clone returns a copy of the batch resources, so they can be subtracted speculatively without modifying the original
+func (r *BatchResources) Clone() BatchResources {
+	return BatchResources{
+		ZKCounters: r.ZKCounters,
+		Bytes:      r.Bytes,
+	}
+}
+
+// Add gives back the other resources (e.g. from a discarded tx) to the remaining ones. It fails without modifying
+// the receiver if any resource would exceed its max
+func (r *BatchResources) Add(other BatchResources, max BatchResources) error {
+	remaining, others, maxs := r.amounts(), other.amounts(), max.amounts()
+	for i := range remaining {
+		if others[i].amount > maxs[i].amount || remaining[i].amount > maxs[i].amount-others[i].amount {
+			return NewBatchRemainingResourcesOverflowError(remaining[i].name)
+		}
+	}
+
+	r.Bytes += other.Bytes
+	r.ZKCounters.SumUp(other.ZKCounters)
+
+	return nil
+}
+
+// RemainingPercent returns, for the bytes and each ZKCounter, the percentage of the max resources that is still available.
+// A resource with a zero max has no share to report, so it's not included
+func (r *BatchResources) RemainingPercent(max BatchResources) map[string]float64 {
+	remaining, maxs := r.amounts(), max.amounts()
+	percent := make(map[string]float64, len(remaining))
+	for i := range remaining {
+		if maxs[i].amount == 0 {
+			continue
+		}
+		percent[remaining[i].name] = float64(remaining[i].amount) * 100 / float64(maxs[i].amount) //nolint:gomnd
 	}
+	return percent
+}
 
-	return err
+// resourceAmount is the amount of one of the resources of a batch
+type resourceAmount struct {
+	name   string
+	amount uint64
+}
+
+// amounts returns the bytes and each ZKCounter of the batch resources, always in the same order
+func (r *BatchResources) amounts() []resourceAmount {
+	return []resourceAmount{
+		{"Bytes", r.Bytes},
+		{"CumulativeGasUsed", r.ZKCounters.CumulativeGasUsed},
+		{"UsedKeccakHashes", uint64(r.ZKCounters.UsedKeccakHashes)},
+		{"UsedPoseidonHashes", uint64(r.ZKCounters.UsedPoseidonHashes)},
+		{"UsedPoseidonPaddings", uint64(r.ZKCounters.UsedPoseidonPaddings)},
+		{"UsedMemAligns", uint64(r.ZKCounters.UsedMemAligns)},
+		{"UsedArithmetics", uint64(r.ZKCounters.UsedArithmetics)},
+		{"UsedBinaries", uint64(r.ZKCounters.UsedBinaries)},
+		{"UsedSteps", uint64(r.ZKCounters.UsedSteps)},
+	}
 }
 
 // InfoReadWrite has information about modified addresses during the execution
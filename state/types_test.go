@@ -0,0 +1,197 @@
+package state_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchResourcesRemainingPercent(t *testing.T) {
+	max := state.BatchResources{
+		Bytes: 1000,
+		ZKCounters: state.ZKCounters{
+			CumulativeGasUsed:    200,
+			UsedKeccakHashes:     100,
+			UsedPoseidonHashes:   100,
+			UsedPoseidonPaddings: 100,
+			UsedMemAligns:        100,
+			UsedArithmetics:      100,
+			UsedBinaries:         100,
+			UsedSteps:            0,
+		},
+	}
+	remaining := state.BatchResources{
+		Bytes: 250,
+		ZKCounters: state.ZKCounters{
+			CumulativeGasUsed:    200,
+			UsedKeccakHashes:     10,
+			UsedPoseidonHashes:   0,
+			UsedPoseidonPaddings: 50,
+			UsedMemAligns:        100,
+			UsedArithmetics:      1,
+			UsedBinaries:         99,
+			UsedSteps:            0,
+		},
+	}
+
+	percent := remaining.RemainingPercent(max)
+	assert.Equal(t, map[string]float64{
+		"Bytes":                25,
+		"CumulativeGasUsed":    100,
+		"UsedKeccakHashes":     10,
+		"UsedPoseidonHashes":   0,
+		"UsedPoseidonPaddings": 50,
+		"UsedMemAligns":        100,
+		"UsedArithmetics":      1,
+		"UsedBinaries":         99,
+	}, percent)
+	// A zero max must not be reported as a fully used resource
+	_, found := percent["UsedSteps"]
+	assert.False(t, found)
+}
+
+func TestBatchResourcesSubUnderflowError(t *testing.T) {
+	testCases := []struct {
+		name              string
+		remaining         state.BatchResources
+		required          state.BatchResources
+		expectedResource  string
+		expectedUnderflow uint64
+		expectedMessage   string
+	}{
+		{
+			name:              "Bytes",
+			remaining:         state.BatchResources{Bytes: 10},
+			required:          state.BatchResources{Bytes: 15},
+			expectedResource:  "Bytes",
+			expectedUnderflow: 5,
+			expectedMessage:   state.ErrBatchResourceBytesUnderflow.Error(),
+		},
+		{
+			name:              "UsedPoseidonPaddings",
+			remaining:         state.BatchResources{Bytes: 10, ZKCounters: state.ZKCounters{UsedPoseidonPaddings: 3}},
+			required:          state.BatchResources{Bytes: 1, ZKCounters: state.ZKCounters{UsedPoseidonPaddings: 10}},
+			expectedResource:  "UsedPoseidonPaddings",
+			expectedUnderflow: 7,
+			expectedMessage:   state.NewBatchRemainingResourcesUnderflowError(nil, "ZKCounter: UsedPoseidonPaddings").Error(),
+		},
+		{
+			name:              "UsedSteps",
+			remaining:         state.BatchResources{ZKCounters: state.ZKCounters{UsedSteps: 100}},
+			required:          state.BatchResources{ZKCounters: state.ZKCounters{UsedSteps: 101}},
+			expectedResource:  "UsedSteps",
+			expectedUnderflow: 1,
+			expectedMessage:   state.NewBatchRemainingResourcesUnderflowError(nil, "ZKCounter: UsedSteps").Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.remaining.Sub(tc.required)
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.expectedMessage)
+
+			var batchErr *state.BatchRemainingResourcesUnderflowError
+			require.True(t, errors.As(err, &batchErr))
+			assert.ErrorIs(t, err, state.ErrBatchRemainingResourcesUnderflow)
+
+			var underflowErr *state.ResourceUnderflowError
+			require.True(t, errors.As(err, &underflowErr))
+			assert.Equal(t, tc.expectedResource, underflowErr.ResourceName)
+			assert.Equal(t, tc.expectedUnderflow, underflowErr.Underflow())
+		})
+	}
+
+	err := (&state.BatchResources{}).Sub(state.BatchResources{Bytes: 1})
+	assert.True(t, errors.Is(err, state.ErrBatchResourceBytesUnderflow))
+	// Only the generic sentinel matches an underflow of another resource
+	err = (&state.BatchResources{}).Sub(state.BatchResources{ZKCounters: state.ZKCounters{UsedSteps: 1}})
+	assert.True(t, errors.Is(err, state.ErrBatchRemainingResourcesUnderflow))
+	assert.False(t, errors.Is(err, state.ErrBatchResourceBytesUnderflow))
+}
+
+func TestBatchResourcesAdd(t *testing.T) {
+	max := state.BatchResources{Bytes: 100, ZKCounters: state.ZKCounters{CumulativeGasUsed: 100, UsedSteps: 100}}
+	remaining := state.BatchResources{Bytes: 100, ZKCounters: state.ZKCounters{CumulativeGasUsed: 100, UsedSteps: 100}}
+	txResources := state.BatchResources{Bytes: 40, ZKCounters: state.ZKCounters{CumulativeGasUsed: 30, UsedSteps: 20}}
+
+	require.NoError(t, remaining.Sub(txResources))
+	require.NoError(t, remaining.Add(txResources, max))
+	assert.Equal(t, max, remaining)
+}
+
+func TestBatchResourcesAddOverflow(t *testing.T) {
+	max := state.BatchResources{Bytes: 100, ZKCounters: state.ZKCounters{CumulativeGasUsed: 100, UsedSteps: 100}}
+
+	testCases := []struct {
+		name             string
+		remaining        state.BatchResources
+		refund           state.BatchResources
+		expectedResource string
+	}{
+		{
+			name:             "Bytes",
+			remaining:        state.BatchResources{Bytes: 90, ZKCounters: state.ZKCounters{CumulativeGasUsed: 50, UsedSteps: 50}},
+			refund:           state.BatchResources{Bytes: 11},
+			expectedResource: "Bytes",
+		},
+		{
+			name:             "UsedSteps",
+			remaining:        state.BatchResources{Bytes: 50, ZKCounters: state.ZKCounters{CumulativeGasUsed: 50, UsedSteps: 50}},
+			refund:           state.BatchResources{Bytes: 10, ZKCounters: state.ZKCounters{UsedSteps: 51}},
+			expectedResource: "UsedSteps",
+		},
+		{
+			name:             "UsedSteps uint32 wraparound",
+			remaining:        state.BatchResources{ZKCounters: state.ZKCounters{UsedSteps: 50}},
+			refund:           state.BatchResources{ZKCounters: state.ZKCounters{UsedSteps: math.MaxUint32}},
+			expectedResource: "UsedSteps",
+		},
+		{
+			name:             "Zero max",
+			remaining:        state.BatchResources{},
+			refund:           state.BatchResources{ZKCounters: state.ZKCounters{UsedBinaries: 1}},
+			expectedResource: "UsedBinaries",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			remaining := tc.remaining
+			err := remaining.Add(tc.refund, max)
+			require.ErrorIs(t, err, state.ErrBatchRemainingResourcesOverflow)
+			assert.ErrorContains(t, err, tc.expectedResource)
+			// the remaining resources must be left untouched
+			assert.Equal(t, tc.remaining, remaining)
+		})
+	}
+}
+
+func TestBatchResourcesSubLeavesReceiverUntouchedOnUnderflow(t *testing.T) {
+	remaining := state.BatchResources{
+		Bytes:      100,
+		ZKCounters: state.ZKCounters{CumulativeGasUsed: 100, UsedKeccakHashes: 10, UsedSteps: 100},
+	}
+	original := remaining.Clone()
+
+	// Bytes and the first counters fit, UsedSteps doesn't
+	err := remaining.Sub(state.BatchResources{
+		Bytes:      50,
+		ZKCounters: state.ZKCounters{CumulativeGasUsed: 50, UsedKeccakHashes: 5, UsedSteps: 101},
+	})
+	require.Error(t, err)
+	assert.Equal(t, original, remaining)
+}
+
+func TestBatchResourcesClone(t *testing.T) {
+	remaining := state.BatchResources{Bytes: 100, ZKCounters: state.ZKCounters{UsedSteps: 100}}
+	speculative := remaining.Clone()
+
+	require.NoError(t, speculative.Sub(state.BatchResources{Bytes: 10, ZKCounters: state.ZKCounters{UsedSteps: 10}}))
+	assert.Equal(t, state.BatchResources{Bytes: 90, ZKCounters: state.ZKCounters{UsedSteps: 90}}, speculative)
+	assert.Equal(t, state.BatchResources{Bytes: 100, ZKCounters: state.ZKCounters{UsedSteps: 100}}, remaining)
+}
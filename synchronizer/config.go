@@ -12,4 +12,9 @@ type Config struct {
 	SyncChunkSize uint64 `mapstructure:"SyncChunkSize"`
 	// TrustedSequencerURL is the rpc url to connect and sync the trusted state
 	TrustedSequencerURL string `mapstructure:"TrustedSequencerURL"`
+	// L1TraceMode records the L1 calls made by the synchronizer to L1TraceFile when it's "record", or serves them
+	// from L1TraceFile when it's "replay" to reproduce a sync without a live L1. Empty disables it
+	L1TraceMode string `mapstructure:"L1TraceMode"`
+	// L1TraceFile is the path of the trace file used by L1TraceMode
+	L1TraceFile string `mapstructure:"L1TraceFile"`
 }
@@ -0,0 +1,311 @@
+package synchronizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// L1TraceModeRecord records the L1 calls of the synchronizer to the trace file
+	L1TraceModeRecord = "record"
+	// L1TraceModeReplay serves the L1 calls of the synchronizer from the trace file
+	L1TraceModeReplay = "replay"
+)
+
+var (
+	// ErrTraceMismatch is returned by the replay etherman when the request is not the next call in the trace
+	ErrTraceMismatch = errors.New("request doesn't match the next call in the trace")
+	// ErrTraceMethodNotRecorded is returned by the replay etherman for the methods that are never recorded
+	ErrTraceMethodNotRecorded = errors.New("method is not recorded in the trace")
+)
+
+// l1TraceEntry is one etherman call of an L1 trace. A trace is a file with one JSON encoded entry per line, in
+// the same order the calls were made
+type l1TraceEntry struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Err    string          `json:"error,omitempty"`
+}
+
+// rollupInfoTraceResult is the recorded result of a GetRollupInfoByBlockRange call
+type rollupInfoTraceResult struct {
+	Blocks []etherman.Block                 `json:"blocks"`
+	Order  map[common.Hash][]etherman.Order `json:"order"`
+}
+
+// newL1TraceEtherman wraps etherMan to record or replay its calls according to cfg.L1TraceMode. The returned
+// closer, if any, is the trace file being recorded
+func newL1TraceEtherman(etherMan ethermanInterface, cfg Config) (ethermanInterface, io.Closer, error) {
+	switch cfg.L1TraceMode {
+	case "":
+		return etherMan, nil, nil
+	case L1TraceModeRecord:
+		f, err := os.Create(cfg.L1TraceFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating L1 trace file %s: %w", cfg.L1TraceFile, err)
+		}
+		log.Infof("recording the L1 calls of the synchronizer to %s", cfg.L1TraceFile)
+		return newRecordingEtherman(etherMan, f), f, nil
+	case L1TraceModeReplay:
+		f, err := os.Open(cfg.L1TraceFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error opening L1 trace file %s: %w", cfg.L1TraceFile, err)
+		}
+		defer f.Close()
+		replay, err := newReplayEtherman(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Infof("replaying %d L1 calls of the synchronizer from %s", len(replay.entries), cfg.L1TraceFile)
+		return replay, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown L1TraceMode %q", cfg.L1TraceMode)
+	}
+}
+
+// recordingEtherman wraps an ethermanInterface and writes each call made by Sync, with its inputs and outputs,
+// to the trace. The rest of the methods are served directly by the wrapped etherman without being recorded
+type recordingEtherman struct {
+	ethermanInterface
+	encoder *json.Encoder
+	mux     sync.Mutex
+}
+
+// newRecordingEtherman creates a new recordingEtherman that writes the trace to w
+func newRecordingEtherman(etherMan ethermanInterface, w io.Writer) *recordingEtherman {
+	return &recordingEtherman{
+		ethermanInterface: etherMan,
+		encoder:           json.NewEncoder(w),
+	}
+}
+
+// HeaderByNumber calls the wrapped etherman and records the call in the trace
+func (r *recordingEtherman) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	header, err := r.ethermanInterface.HeaderByNumber(ctx, number)
+	r.record("HeaderByNumber", []interface{}{number}, header, err)
+	return header, err
+}
+
+// GetRollupInfoByBlockRange calls the wrapped etherman and records the call in the trace
+func (r *recordingEtherman) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error) {
+	blocks, order, err := r.ethermanInterface.GetRollupInfoByBlockRange(ctx, fromBlock, toBlock)
+	r.record("GetRollupInfoByBlockRange", []interface{}{fromBlock, toBlock}, rollupInfoTraceResult{Blocks: blocks, Order: order}, err)
+	return blocks, order, err
+}
+
+// EthBlockByNumber calls the wrapped etherman and records the call in the trace. The block is stored RLP encoded
+func (r *recordingEtherman) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error) {
+	block, err := r.ethermanInterface.EthBlockByNumber(ctx, blockNumber)
+	var encodedBlock []byte
+	if block != nil {
+		var encodeErr error
+		encodedBlock, encodeErr = rlp.EncodeToBytes(block)
+		if encodeErr != nil {
+			log.Errorf("error encoding block %d to record it. Error: %v", blockNumber, encodeErr)
+		}
+	}
+	r.record("EthBlockByNumber", []interface{}{blockNumber}, encodedBlock, err)
+	return block, err
+}
+
+// GetLatestBatchNumber calls the wrapped etherman and records the call in the trace
+func (r *recordingEtherman) GetLatestBatchNumber(ctx context.Context) (uint64, error) {
+	batchNumber, err := r.ethermanInterface.GetLatestBatchNumber(ctx)
+	r.record("GetLatestBatchNumber", []interface{}{}, batchNumber, err)
+	return batchNumber, err
+}
+
+// GetLatestVerifiedBatchNum calls the wrapped etherman and records the call in the trace
+func (r *recordingEtherman) GetLatestVerifiedBatchNum() (uint64, error) {
+	batchNumber, err := r.ethermanInterface.GetLatestVerifiedBatchNum()
+	r.record("GetLatestVerifiedBatchNum", []interface{}{}, batchNumber, err)
+	return batchNumber, err
+}
+
+// VerifyGenBlockNumber calls the wrapped etherman and records the call in the trace
+func (r *recordingEtherman) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
+	valid, err := r.ethermanInterface.VerifyGenBlockNumber(ctx, genBlockNumber)
+	r.record("VerifyGenBlockNumber", []interface{}{genBlockNumber}, valid, err)
+	return valid, err
+}
+
+func (r *recordingEtherman) record(method string, params []interface{}, result interface{}, err error) {
+	entry := l1TraceEntry{Method: method}
+	encodedParams, encodeErr := json.Marshal(params)
+	if encodeErr != nil {
+		log.Errorf("error encoding the params of the %s call to record it. Error: %v", method, encodeErr)
+		return
+	}
+	entry.Params = encodedParams
+	entry.Result, encodeErr = json.Marshal(result)
+	if encodeErr != nil {
+		log.Errorf("error encoding the result of the %s call to record it. Error: %v", method, encodeErr)
+		return
+	}
+	if err != nil {
+		entry.Err = err.Error()
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if encodeErr := r.encoder.Encode(entry); encodeErr != nil {
+		log.Errorf("error recording %s call. Error: %v", method, encodeErr)
+	}
+}
+
+// replayEtherman serves the calls made by Sync from a trace recorded by recordingEtherman, in the same order the
+// calls were recorded, so a sync can be reproduced without a live L1. The methods that are never recorded return
+// ErrTraceMethodNotRecorded. Recorded errors are replayed with the same message but not the same value, so
+// checks against sentinel errors like etherman.ErrNotFound don't match them
+type replayEtherman struct {
+	entries []l1TraceEntry
+	next    int
+	mux     sync.Mutex
+}
+
+// newReplayEtherman creates a new replayEtherman reading the whole trace from r
+func newReplayEtherman(r io.Reader) (*replayEtherman, error) {
+	var entries []l1TraceEntry
+	decoder := json.NewDecoder(r)
+	for {
+		var entry l1TraceEntry
+		err := decoder.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error decoding L1 trace entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+	return &replayEtherman{entries: entries}, nil
+}
+
+// HeaderByNumber returns the next recorded response, checking that it was recorded for the same block
+func (r *replayEtherman) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	var header *ethTypes.Header
+	err := r.replay("HeaderByNumber", []interface{}{number}, &header)
+	return header, err
+}
+
+// GetRollupInfoByBlockRange returns the next recorded response, checking that it was recorded for the same range
+func (r *replayEtherman) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error) {
+	var result rollupInfoTraceResult
+	err := r.replay("GetRollupInfoByBlockRange", []interface{}{fromBlock, toBlock}, &result)
+	return result.Blocks, result.Order, err
+}
+
+// EthBlockByNumber returns the next recorded response, checking that it was recorded for the same block
+func (r *replayEtherman) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error) {
+	var encodedBlock []byte
+	err := r.replay("EthBlockByNumber", []interface{}{blockNumber}, &encodedBlock)
+	if len(encodedBlock) == 0 {
+		return nil, err
+	}
+	var block ethTypes.Block
+	if decodeErr := rlp.DecodeBytes(encodedBlock, &block); decodeErr != nil {
+		return nil, fmt.Errorf("error decoding recorded block %d: %w", blockNumber, decodeErr)
+	}
+	return &block, err
+}
+
+// GetLatestBatchNumber returns the next recorded response
+func (r *replayEtherman) GetLatestBatchNumber(ctx context.Context) (uint64, error) {
+	var batchNumber uint64
+	err := r.replay("GetLatestBatchNumber", []interface{}{}, &batchNumber)
+	return batchNumber, err
+}
+
+// GetLatestVerifiedBatchNum returns the next recorded response
+func (r *replayEtherman) GetLatestVerifiedBatchNum() (uint64, error) {
+	var batchNumber uint64
+	err := r.replay("GetLatestVerifiedBatchNum", []interface{}{}, &batchNumber)
+	return batchNumber, err
+}
+
+// VerifyGenBlockNumber returns the next recorded response, checking that it was recorded for the same block
+func (r *replayEtherman) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
+	var valid bool
+	err := r.replay("VerifyGenBlockNumber", []interface{}{genBlockNumber}, &valid)
+	return valid, err
+}
+
+// GetRollupInfoByBlockRanges is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) GetRollupInfoByBlockRanges(ctx context.Context, blockRanges []etherman.BlockRange) (map[etherman.BlockRange]etherman.RollupInfoByBlockRange, error) {
+	return nil, notRecordedError("GetRollupInfoByBlockRanges")
+}
+
+// GetRollupInfoByBlockRangeWithReceipts is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) GetRollupInfoByBlockRangeWithReceipts(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, map[common.Hash]*ethTypes.Receipt, error) {
+	return nil, nil, nil, notRecordedError("GetRollupInfoByBlockRangeWithReceipts")
+}
+
+// EthBlockByHash is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) EthBlockByHash(ctx context.Context, blockHash common.Hash) (*ethTypes.Block, error) {
+	return nil, notRecordedError("EthBlockByHash")
+}
+
+// GetTrustedSequencerURL is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) GetTrustedSequencerURL(ctx context.Context) (string, error) {
+	return "", notRecordedError("GetTrustedSequencerURL")
+}
+
+// VerifyGenBlockNumbers is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) VerifyGenBlockNumbers(ctx context.Context, genBlockNumbers []uint64) (map[uint64]bool, error) {
+	return nil, notRecordedError("VerifyGenBlockNumbers")
+}
+
+// GetLatestGlobalExitRoot is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error) {
+	return common.Hash{}, notRecordedError("GetLatestGlobalExitRoot")
+}
+
+// GetForkIDByBlockNumber is not recorded, it returns ErrTraceMethodNotRecorded
+func (r *replayEtherman) GetForkIDByBlockNumber(ctx context.Context, genBlockNumber uint64, blockNumber uint64) (uint64, error) {
+	return 0, notRecordedError("GetForkIDByBlockNumber")
+}
+
+// replay checks that the next entry of the trace was recorded for the same method and params, and decodes its
+// result into result. The trace is not consumed when the call doesn't match
+func (r *replayEtherman) replay(method string, params []interface{}, result interface{}) error {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("error encoding the params of the %s call: %w", method, err)
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.next >= len(r.entries) {
+		return fmt.Errorf("%w: trace exhausted after %d calls, got %s%s", ErrTraceMismatch, len(r.entries), method, encodedParams)
+	}
+	entry := r.entries[r.next]
+	if entry.Method != method || !bytes.Equal(entry.Params, encodedParams) {
+		return fmt.Errorf("%w: call %d was recorded as %s%s, got %s%s", ErrTraceMismatch, r.next, entry.Method, entry.Params, method, encodedParams)
+	}
+	if len(entry.Result) > 0 {
+		if err := json.Unmarshal(entry.Result, result); err != nil {
+			return fmt.Errorf("error decoding the result of L1 trace entry %d: %w", r.next, err)
+		}
+	}
+	r.next++
+	if entry.Err != "" {
+		return errors.New(entry.Err)
+	}
+	return nil
+}
+
+func notRecordedError(method string) error {
+	return fmt.Errorf("%w: %s", ErrTraceMethodNotRecorded, method)
+}
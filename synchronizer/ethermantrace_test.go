@@ -0,0 +1,170 @@
+package synchronizer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevm"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplayEtherman(t *testing.T) {
+	ctx := context.Background()
+	blockHash := common.HexToHash("0x2")
+	blocks := []etherman.Block{
+		{
+			BlockNumber: 2,
+			BlockHash:   blockHash,
+			ParentHash:  common.HexToHash("0x1"),
+			SequencedBatches: [][]etherman.SequencedBatch{{
+				{
+					BatchNumber:   1,
+					SequencerAddr: common.HexToAddress("0x222"),
+					TxHash:        common.HexToHash("0x3"),
+					Coinbase:      common.HexToAddress("0x222"),
+					PolygonZkEVMBatchData: polygonzkevm.PolygonZkEVMBatchData{
+						Transactions:       []byte{0x01, 0x02},
+						GlobalExitRoot:     [32]byte{0x04},
+						Timestamp:          1690000000,
+						MinForcedTimestamp: 0,
+					},
+				},
+			}},
+			ForcedBatches: []etherman.ForcedBatch{
+				{
+					BlockNumber:       2,
+					ForcedBatchNumber: 1,
+					RawTxsData:        []byte{0x05},
+					ForcedAt:          time.Unix(1690000000, 0).UTC(),
+				},
+			},
+			ReceivedAt: time.Unix(1690000001, 0).UTC(),
+		},
+	}
+	order := map[common.Hash][]etherman.Order{
+		blockHash: {{Name: etherman.ForcedBatchesOrder, Pos: 0}, {Name: etherman.SequenceBatchesOrder, Pos: 0}},
+	}
+	toBlock := uint64(10)
+	header := &ethTypes.Header{
+		ParentHash: common.HexToHash("0x1"),
+		Number:     big.NewInt(11),
+		Difficulty: big.NewInt(0),
+		Time:       1690000002,
+	}
+	ethBlock := ethTypes.NewBlockWithHeader(header)
+
+	m := newEthermanMock(t)
+	m.On("VerifyGenBlockNumber", ctx, uint64(1)).Return(true, nil).Once()
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(1), &toBlock).Return(blocks, order, nil).Once()
+	m.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header, nil).Once()
+	m.On("EthBlockByNumber", ctx, uint64(11)).Return(ethBlock, nil).Once()
+	m.On("GetLatestBatchNumber", ctx).Return(uint64(5), nil).Once()
+	m.On("GetLatestVerifiedBatchNum").Return(uint64(4), nil).Once()
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(11), (*uint64)(nil)).Return(nil, nil, errors.New("provider unavailable")).Once()
+	m.On("GetLatestGlobalExitRoot", ctx).Return(common.HexToHash("0x6"), nil).Once()
+
+	var trace bytes.Buffer
+	recorder := newRecordingEtherman(m, &trace)
+	valid, err := recorder.VerifyGenBlockNumber(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	recordedBlocks, recordedOrder, err := recorder.GetRollupInfoByBlockRange(ctx, 1, &toBlock)
+	require.NoError(t, err)
+	assert.Equal(t, blocks, recordedBlocks)
+	assert.Equal(t, order, recordedOrder)
+	recordedHeader, err := recorder.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, header, recordedHeader)
+	recordedBlock, err := recorder.EthBlockByNumber(ctx, 11)
+	require.NoError(t, err)
+	assert.Equal(t, ethBlock.Hash(), recordedBlock.Hash())
+	batchNumber, err := recorder.GetLatestBatchNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), batchNumber)
+	verifiedBatchNumber, err := recorder.GetLatestVerifiedBatchNum()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), verifiedBatchNumber)
+	_, _, err = recorder.GetRollupInfoByBlockRange(ctx, 11, nil)
+	require.EqualError(t, err, "provider unavailable")
+	// Methods not used by Sync are served by the wrapped etherman and not recorded
+	_, err = recorder.GetLatestGlobalExitRoot(ctx)
+	require.NoError(t, err)
+
+	replay, err := newReplayEtherman(bytes.NewReader(trace.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, 7, len(replay.entries))
+
+	// A request not matching the next recorded call fails without consuming the trace
+	_, _, err = replay.GetRollupInfoByBlockRange(ctx, 1, &toBlock)
+	require.ErrorIs(t, err, ErrTraceMismatch)
+	_, err = replay.VerifyGenBlockNumber(ctx, 2)
+	require.ErrorIs(t, err, ErrTraceMismatch)
+
+	valid, err = replay.VerifyGenBlockNumber(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	replayedBlocks, replayedOrder, err := replay.GetRollupInfoByBlockRange(ctx, 1, &toBlock)
+	require.NoError(t, err)
+	assert.Equal(t, blocks, replayedBlocks)
+	assert.Equal(t, order, replayedOrder)
+	replayedHeader, err := replay.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), replayedHeader.Hash())
+	replayedBlock, err := replay.EthBlockByNumber(ctx, 11)
+	require.NoError(t, err)
+	assert.Equal(t, ethBlock.Hash(), replayedBlock.Hash())
+	assert.Equal(t, ethBlock.ParentHash(), replayedBlock.ParentHash())
+	batchNumber, err = replay.GetLatestBatchNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), batchNumber)
+	verifiedBatchNumber, err = replay.GetLatestVerifiedBatchNum()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), verifiedBatchNumber)
+	_, _, err = replay.GetRollupInfoByBlockRange(ctx, 11, nil)
+	require.EqualError(t, err, "provider unavailable")
+
+	_, _, err = replay.GetRollupInfoByBlockRange(ctx, 12, nil)
+	require.ErrorIs(t, err, ErrTraceMismatch)
+	_, err = replay.GetLatestGlobalExitRoot(ctx)
+	require.ErrorIs(t, err, ErrTraceMethodNotRecorded)
+}
+
+func TestNewL1TraceEtherman(t *testing.T) {
+	ctx := context.Background()
+	traceFile := filepath.Join(t.TempDir(), "l1trace.jsonl")
+	m := newEthermanMock(t)
+	m.On("GetLatestBatchNumber", ctx).Return(uint64(5), nil).Once()
+
+	etherMan, closer, err := newL1TraceEtherman(m, Config{})
+	require.NoError(t, err)
+	assert.Nil(t, closer)
+	assert.Equal(t, m, etherMan)
+
+	_, _, err = newL1TraceEtherman(m, Config{L1TraceMode: "unknown"})
+	require.Error(t, err)
+
+	_, _, err = newL1TraceEtherman(nil, Config{L1TraceMode: L1TraceModeReplay, L1TraceFile: traceFile})
+	require.Error(t, err)
+
+	etherMan, closer, err = newL1TraceEtherman(m, Config{L1TraceMode: L1TraceModeRecord, L1TraceFile: traceFile})
+	require.NoError(t, err)
+	_, err = etherMan.GetLatestBatchNumber(ctx)
+	require.NoError(t, err)
+	require.NoError(t, closer.Close())
+
+	etherMan, closer, err = newL1TraceEtherman(nil, Config{L1TraceMode: L1TraceModeReplay, L1TraceFile: traceFile})
+	require.NoError(t, err)
+	assert.Nil(t, closer)
+	batchNumber, err := etherMan.GetLatestBatchNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), batchNumber)
+}
@@ -18,11 +18,17 @@ import (
 type ethermanInterface interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
 	GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error)
+	GetRollupInfoByBlockRanges(ctx context.Context, blockRanges []etherman.BlockRange) (map[etherman.BlockRange]etherman.RollupInfoByBlockRange, error)
+	GetRollupInfoByBlockRangeWithReceipts(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, map[common.Hash]*ethTypes.Receipt, error)
 	EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error)
-	GetLatestBatchNumber() (uint64, error)
-	GetTrustedSequencerURL() (string, error)
+	EthBlockByHash(ctx context.Context, blockHash common.Hash) (*ethTypes.Block, error)
+	GetLatestBatchNumber(ctx context.Context) (uint64, error)
+	GetTrustedSequencerURL(ctx context.Context) (string, error)
 	VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error)
+	VerifyGenBlockNumbers(ctx context.Context, genBlockNumbers []uint64) (map[uint64]bool, error)
 	GetLatestVerifiedBatchNum() (uint64, error)
+	GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error)
+	GetForkIDByBlockNumber(ctx context.Context, genBlockNumber uint64, blockNumber uint64) (uint64, error)
 }
 
 // stateInterface gathers the methods required to interact with the state.
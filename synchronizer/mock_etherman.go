@@ -20,6 +20,32 @@ type ethermanMock struct {
 	mock.Mock
 }
 
+// EthBlockByHash provides a mock function with given fields: ctx, blockHash
+func (_m *ethermanMock) EthBlockByHash(ctx context.Context, blockHash common.Hash) (*types.Block, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Block, error)); ok {
+		return rf(ctx, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Block); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EthBlockByNumber provides a mock function with given fields: ctx, blockNumber
 func (_m *ethermanMock) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*types.Block, error) {
 	ret := _m.Called(ctx, blockNumber)
@@ -46,23 +72,73 @@ func (_m *ethermanMock) EthBlockByNumber(ctx context.Context, blockNumber uint64
 	return r0, r1
 }
 
-// GetLatestBatchNumber provides a mock function with given fields:
-func (_m *ethermanMock) GetLatestBatchNumber() (uint64, error) {
-	ret := _m.Called()
+// GetForkIDByBlockNumber provides a mock function with given fields: ctx, genBlockNumber, blockNumber
+func (_m *ethermanMock) GetForkIDByBlockNumber(ctx context.Context, genBlockNumber uint64, blockNumber uint64) (uint64, error) {
+	ret := _m.Called(ctx, genBlockNumber, blockNumber)
 
 	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (uint64, error)); ok {
+		return rf(ctx, genBlockNumber, blockNumber)
 	}
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) uint64); ok {
+		r0 = rf(ctx, genBlockNumber, blockNumber)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, genBlockNumber, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBatchNumber provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetLatestBatchNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestGlobalExitRoot provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (common.Hash, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) common.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,23 +205,93 @@ func (_m *ethermanMock) GetRollupInfoByBlockRange(ctx context.Context, fromBlock
 	return r0, r1, r2
 }
 
-// GetTrustedSequencerURL provides a mock function with given fields:
-func (_m *ethermanMock) GetTrustedSequencerURL() (string, error) {
-	ret := _m.Called()
+// GetRollupInfoByBlockRangeWithReceipts provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *ethermanMock) GetRollupInfoByBlockRangeWithReceipts(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, map[common.Hash]*types.Receipt, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	var r0 []etherman.Block
+	var r1 map[common.Hash][]etherman.Order
+	var r2 map[common.Hash]*types.Receipt
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, map[common.Hash]*types.Receipt, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *uint64) []etherman.Block); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]etherman.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, *uint64) map[common.Hash][]etherman.Order); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[common.Hash][]etherman.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, *uint64) map[common.Hash]*types.Receipt); ok {
+		r2 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(map[common.Hash]*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, uint64, *uint64) error); ok {
+		r3 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetRollupInfoByBlockRanges provides a mock function with given fields: ctx, blockRanges
+func (_m *ethermanMock) GetRollupInfoByBlockRanges(ctx context.Context, blockRanges []etherman.BlockRange) (map[etherman.BlockRange]etherman.RollupInfoByBlockRange, error) {
+	ret := _m.Called(ctx, blockRanges)
+
+	var r0 map[etherman.BlockRange]etherman.RollupInfoByBlockRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []etherman.BlockRange) (map[etherman.BlockRange]etherman.RollupInfoByBlockRange, error)); ok {
+		return rf(ctx, blockRanges)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []etherman.BlockRange) map[etherman.BlockRange]etherman.RollupInfoByBlockRange); ok {
+		r0 = rf(ctx, blockRanges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[etherman.BlockRange]etherman.RollupInfoByBlockRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []etherman.BlockRange) error); ok {
+		r1 = rf(ctx, blockRanges)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrustedSequencerURL provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetTrustedSequencerURL(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -203,6 +349,32 @@ func (_m *ethermanMock) VerifyGenBlockNumber(ctx context.Context, genBlockNumber
 	return r0, r1
 }
 
+// VerifyGenBlockNumbers provides a mock function with given fields: ctx, genBlockNumbers
+func (_m *ethermanMock) VerifyGenBlockNumbers(ctx context.Context, genBlockNumbers []uint64) (map[uint64]bool, error) {
+	ret := _m.Called(ctx, genBlockNumbers)
+
+	var r0 map[uint64]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) (map[uint64]bool, error)); ok {
+		return rf(ctx, genBlockNumbers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) map[uint64]bool); ok {
+		r0 = rf(ctx, genBlockNumbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uint64]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint64) error); ok {
+		r1 = rf(ctx, genBlockNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTnewEthermanMock interface {
 	mock.TestingT
 	Cleanup(func())
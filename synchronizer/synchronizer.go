@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"strings"
@@ -60,6 +61,8 @@ type ClientSynchronizer struct {
 	proverID string
 	// Previous value returned by state.GetStoredFlushID, is used for decide if write a log or not
 	previousExecutorFlushID uint64
+	// l1TraceFile is the file the L1 calls are recorded to when L1TraceMode is "record"
+	l1TraceFile io.Closer
 }
 
 // NewSynchronizer creates and initializes an instance of Synchronizer
@@ -73,6 +76,10 @@ func NewSynchronizer(
 	eventLog *event.EventLog,
 	genesis state.Genesis,
 	cfg Config) (Synchronizer, error) {
+	ethMan, l1TraceFile, err := newL1TraceEtherman(ethMan, cfg)
+	if err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	metrics.Register()
 
@@ -90,6 +97,7 @@ func NewSynchronizer(
 		cfg:                     cfg,
 		proverID:                "",
 		previousExecutorFlushID: 0,
+		l1TraceFile:             l1TraceFile,
 	}, nil
 }
 
@@ -224,7 +232,7 @@ func (s *ClientSynchronizer) Sync() error {
 			return nil
 		case <-time.After(waitDuration):
 			start := time.Now()
-			latestSequencedBatchNumber, err := s.etherMan.GetLatestBatchNumber()
+			latestSequencedBatchNumber, err := s.etherMan.GetLatestBatchNumber(s.ctx)
 			if err != nil {
 				log.Warn("error getting latest sequenced batch in the rollup. Error: ", err)
 				continue
@@ -653,6 +661,11 @@ func (s *ClientSynchronizer) checkReorg(latestBlock *state.Block) (*state.Block,
 // Stop function stops the synchronizer
 func (s *ClientSynchronizer) Stop() {
 	s.cancelCtx()
+	if s.l1TraceFile != nil {
+		if err := s.l1TraceFile.Close(); err != nil {
+			log.Errorf("error closing L1 trace file. Error: %v", err)
+		}
+	}
 }
 
 func (s *ClientSynchronizer) checkTrustedState(batch state.Batch, tBatch *state.Batch, newRoot common.Hash, dbTx pgx.Tx) bool {
@@ -1426,7 +1439,7 @@ func (s *ClientSynchronizer) processTrustedBatch(trustedBatch *types.Batch, dbTx
 }
 
 func (s *ClientSynchronizer) reorgPool(dbTx pgx.Tx) error {
-	latestBatchNum, err := s.etherMan.GetLatestBatchNumber()
+	latestBatchNum, err := s.etherMan.GetLatestBatchNumber(s.ctx)
 	if err != nil {
 		log.Error("error getting the latestBatchNumber virtualized in the smc. Error: ", err)
 		return err
@@ -134,7 +134,7 @@ func TestForcedBatch(t *testing.T) {
 				Once()
 
 			m.Etherman.
-				On("GetLatestBatchNumber").
+				On("GetLatestBatchNumber", ctx).
 				Return(uint64(10), nil).
 				Once()
 
@@ -378,7 +378,7 @@ func TestSequenceForcedBatch(t *testing.T) {
 				Once()
 
 			m.Etherman.
-				On("GetLatestBatchNumber").
+				On("GetLatestBatchNumber", ctx).
 				Return(uint64(10), nil).
 				Once()
 